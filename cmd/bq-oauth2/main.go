@@ -0,0 +1,101 @@
+// Command bq-oauth2 authorizes BigQuery access with an OAuth2 flow and stores the client config and token
+// for the driver oauth2ClientURL and oauth2TokenURL DSN options.
+//
+// Usage:
+//
+//	bq-oauth2 -project myproject [-dataset mydataset] [-client client_secret.json] [-flow Manual|Device|Browser] [-pkce] [-base ~/.secret/bigquery] [-key blowfish://default]
+//
+// Client file is Google Cloud console OAuth2 client JSON, the gcloud client is used by default.
+// The printed DSN embeds the stored config and token URLs.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	bq "github.com/viant/bigquery"
+	"github.com/viant/scy/auth/gcp/client"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+func main() {
+	project := flag.String("project", "", "BigQuery project ID")
+	dataset := flag.String("dataset", "", "optional default dataset")
+	clientFile := flag.String("client", "", "OAuth2 client JSON file, defaults to gcloud client")
+	flow := flag.String("flow", bq.OAuth2FlowManual, "authorization flow: Manual, Device or Browser")
+	pkce := flag.Bool("pkce", true, "use PKCE with Manual and Browser flows")
+	base := flag.String("base", defaultBaseURL(), "base URL of stored config and token")
+	key := flag.String("key", "blowfish://default", "scy key encrypting stored token, empty stores plain JSON")
+	flag.Parse()
+	if *project == "" {
+		flag.Usage()
+		log.Fatal("-project is required")
+	}
+	DSN, err := run(context.Background(), *project, *dataset, *clientFile, *flow, *pkce, *base, *key)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(DSN)
+}
+
+func run(ctx context.Context, project, dataset, clientFile, flow string, pkce bool, base, key string) (string, error) {
+	config, err := loadConfig(clientFile)
+	if err != nil {
+		return "", err
+	}
+	manager := bq.NewOAuth2Manager(bq.WithAuthFlow(flow), bq.WithPKCE(pkce), bq.WithBaseURL(base), bq.WithTokenKey(key))
+	token, err := manager.Token(ctx, config)
+	if err != nil {
+		return "", fmt.Errorf("failed to authorize: %w", err)
+	}
+	configURL, err := manager.WithConfigURL(ctx, config)
+	if err != nil {
+		return "", fmt.Errorf("failed to store config: %w", err)
+	}
+	tokenURL, err := manager.WithTokenURL(ctx, token)
+	if err != nil {
+		return "", fmt.Errorf("failed to store token: %w", err)
+	}
+	return dsn(project, dataset, configURL, tokenURL, key), nil
+}
+
+// loadConfig loads Google Cloud console OAuth2 client JSON or returns the gcloud client
+func loadConfig(location string) (*oauth2.Config, error) {
+	if location == "" {
+		return client.NewGCloud(), nil
+	}
+	data, err := os.ReadFile(location)
+	if err != nil {
+		return nil, err
+	}
+	config, err := google.ConfigFromJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client file: %v, %w", location, err)
+	}
+	return config, nil
+}
+
+// dsn returns driver DSN using the stored config and token, empty key is kept as it selects plain JSON token
+func dsn(project, dataset, configURL, tokenURL, key string) string {
+	query := url.Values{}
+	query.Set("oauth2ClientURL", configURL)
+	query.Set("oauth2TokenURL", tokenURL)
+	if key != "blowfish://default" {
+		query.Set("oauth2TokenKey", key)
+	}
+	return "bigquery://" + project + "/" + dataset + "?" + query.Encode()
+}
+
+func defaultBaseURL() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "bigquery")
+	}
+	return filepath.Join(home, ".secret", "bigquery")
+}
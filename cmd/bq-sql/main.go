@@ -0,0 +1,78 @@
+// Command bq-sql is an interactive SQL shell and batch runner built on the BigQuery driver.
+//
+// Usage:
+//
+//	bq-sql -dsn bigquery://myproject/mydataset                          # interactive shell
+//	bq-sql -dsn bigquery://myproject/mydataset -e "SELECT 1"            # single statement
+//	bq-sql -dsn bigquery://myproject/mydataset -f script.sql -format csv
+//	cat script.sql | bq-sql -dsn bigquery://myproject/mydataset -format json
+//
+// Statements are separated with semicolons, the shell supports the following commands:
+// \describe <table>, \format <table|csv|json>, \dryrun <on|off>, \stats <on|off>, \help and \quit.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"github.com/viant/bigquery"
+	"log"
+	"os"
+)
+
+func main() {
+	dsn := flag.String("dsn", os.Getenv("BQ_DSN"), "driver DSN i.e. bigquery://myproject/mydataset, defaults to BQ_DSN env variable")
+	file := flag.String("f", "", "SQL script file")
+	SQL := flag.String("e", "", "SQL statements to execute")
+	format := flag.String("format", formatTable, "result format: table, csv or json")
+	dryRun := flag.Bool("dry-run", false, "validate queries and show estimated statistics without running them")
+	stats := flag.Bool("stats", false, "show job statistics")
+	flag.Parse()
+	if err := run(*dsn, *file, *SQL, *format, *dryRun, *stats); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(dsn, file, SQL, format string, dryRun, stats bool) error {
+	if dsn == "" {
+		flag.Usage()
+		return fmt.Errorf("dsn was empty")
+	}
+	if _, err := bigquery.ParseDSN(dsn); err != nil {
+		return err
+	}
+	if !isFormat(format) {
+		return fmt.Errorf("unsupported format: %v, supported: [%v|%v|%v]", format, formatTable, formatCSV, formatJSON)
+	}
+	db, err := sql.Open("bigquery", dsn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	aShell := &shell{conn: conn, out: os.Stdout, format: format, dryRun: dryRun, stats: stats}
+	switch {
+	case SQL != "":
+		return aShell.runScript(ctx, SQL)
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		return aShell.runScript(ctx, string(data))
+	case !isTerminal(os.Stdin):
+		return aShell.runReader(ctx, os.Stdin, false)
+	}
+	return aShell.runReader(ctx, os.Stdin, true)
+}
+
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
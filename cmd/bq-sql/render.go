@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	formatTable = "table"
+	formatCSV   = "csv"
+	formatJSON  = "json"
+	nullValue   = "NULL"
+)
+
+// resultRows represents rows to render, implemented by *sql.Rows
+type resultRows interface {
+	Columns() ([]string, error)
+	Next() bool
+	Scan(dest ...interface{}) error
+	Err() error
+}
+
+// renderer renders result rows
+type renderer struct {
+	format string
+	out    io.Writer
+}
+
+func newRenderer(format string, out io.Writer) *renderer {
+	return &renderer{format: format, out: out}
+}
+
+func isFormat(format string) bool {
+	switch format {
+	case formatTable, formatCSV, formatJSON:
+		return true
+	}
+	return false
+}
+
+// render renders rows, returns number of rendered rows
+func (r *renderer) render(rows resultRows) (int, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	var write func(values []interface{}) error
+	var flush func() error
+	switch r.format {
+	case formatCSV:
+		writer := csv.NewWriter(r.out)
+		if err = writer.Write(columns); err != nil {
+			return 0, err
+		}
+		write = func(values []interface{}) error {
+			return writer.Write(textValues(values))
+		}
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	case formatJSON:
+		write = func(values []interface{}) error {
+			return writeJSONRecord(r.out, columns, values)
+		}
+		flush = func() error { return nil }
+	default:
+		writer := tabwriter.NewWriter(r.out, 0, 0, 2, ' ', 0)
+		header := make([]interface{}, len(columns))
+		separator := make([]interface{}, len(columns))
+		for i, column := range columns {
+			header[i] = column
+			separator[i] = dashes(len(column))
+		}
+		writeTableRow(writer, header)
+		writeTableRow(writer, separator)
+		write = func(values []interface{}) error {
+			writeTableRow(writer, values)
+			return nil
+		}
+		flush = writer.Flush
+	}
+	count := 0
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if err = rows.Scan(pointers...); err != nil {
+			return count, err
+		}
+		if err = write(values); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err = rows.Err(); err != nil {
+		return count, err
+	}
+	return count, flush()
+}
+
+func writeTableRow(writer io.Writer, values []interface{}) {
+	for i, value := range textValues(values) {
+		if i > 0 {
+			fmt.Fprint(writer, "\t")
+		}
+		fmt.Fprint(writer, value)
+	}
+	fmt.Fprint(writer, "\n")
+}
+
+func writeJSONRecord(out io.Writer, columns []string, values []interface{}) error {
+	buffer := new(bytes.Buffer)
+	buffer.WriteByte('{')
+	for i, column := range columns {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		key, _ := json.Marshal(column)
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		value, err := json.Marshal(jsonValue(values[i]))
+		if err != nil {
+			return fmt.Errorf("failed to encode column: %v, %w", column, err)
+		}
+		buffer.Write(value)
+	}
+	buffer.WriteString("}\n")
+	_, err := out.Write(buffer.Bytes())
+	return err
+}
+
+func jsonValue(value interface{}) interface{} {
+	switch actual := value.(type) {
+	case big.Rat:
+		return json.Number(actual.FloatString(9))
+	case *big.Rat:
+		if actual == nil {
+			return nil
+		}
+		return json.Number(actual.FloatString(9))
+	}
+	return value
+}
+
+// textValues returns text representation of values, composite values are JSON encoded
+func textValues(values []interface{}) []string {
+	var result = make([]string, len(values))
+	for i, value := range values {
+		result[i] = textValue(value)
+	}
+	return result
+}
+
+func textValue(value interface{}) string {
+	switch actual := value.(type) {
+	case nil:
+		return nullValue
+	case string:
+		return actual
+	case []byte:
+		return base64.StdEncoding.EncodeToString(actual)
+	case time.Time:
+		return actual.Format(time.RFC3339Nano)
+	case int, int64, float64, float32, bool:
+		return fmt.Sprint(actual)
+	case big.Rat, *big.Rat:
+		data, _ := json.Marshal(jsonValue(actual))
+		return string(data)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func dashes(count int) string {
+	return string(bytes.Repeat([]byte("-"), count))
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/bigquery"
+	"github.com/viant/bigquery/reader"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const prompt = "bq> "
+
+var readerOptionExpr = regexp.MustCompile(`(?i)'Reader:([^']*)'`)
+
+// shell executes SQL statements and shell commands rendering results to out
+type shell struct {
+	conn   *sql.Conn
+	out    io.Writer
+	format string
+	dryRun bool
+	stats  bool
+}
+
+// runScript executes semicolon separated statements
+func (s *shell) runScript(ctx context.Context, script string) error {
+	for _, SQL := range splitStatements(script) {
+		if err := s.execute(ctx, SQL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runReader executes statements read from input, interactive mode reports errors and continues
+func (s *shell) runReader(ctx context.Context, input io.Reader, interactive bool) error {
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 1024*1024), 64*1024*1024)
+	var buffer strings.Builder
+	s.prompt(interactive, buffer.Len() > 0)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if buffer.Len() == 0 && strings.HasPrefix(trimmed, `\`) {
+			quit, err := s.command(ctx, trimmed)
+			if quit {
+				return nil
+			}
+			if err = s.handle(err, interactive); err != nil {
+				return err
+			}
+			s.prompt(interactive, false)
+			continue
+		}
+		buffer.WriteString(line)
+		buffer.WriteString("\n")
+		statements := splitStatements(buffer.String())
+		if !strings.HasSuffix(trimmed, ";") || len(statements) == 0 {
+			s.prompt(interactive, buffer.Len() > 0)
+			continue
+		}
+		buffer.Reset()
+		for _, SQL := range statements {
+			if err := s.handle(s.execute(ctx, SQL), interactive); err != nil {
+				return err
+			}
+		}
+		s.prompt(interactive, false)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if buffer.Len() > 0 {
+		return s.handle(s.runScript(ctx, buffer.String()), interactive)
+	}
+	return nil
+}
+
+func (s *shell) prompt(interactive, continuation bool) {
+	if !interactive {
+		return
+	}
+	if continuation {
+		fmt.Fprint(s.out, "  > ")
+		return
+	}
+	fmt.Fprint(s.out, prompt)
+}
+
+func (s *shell) handle(err error, interactive bool) error {
+	if err == nil || !interactive {
+		return err
+	}
+	fmt.Fprintf(s.out, "error: %v\n", err)
+	return nil
+}
+
+// command executes shell command, returns true if shell should quit
+func (s *shell) command(ctx context.Context, line string) (bool, error) {
+	fields := strings.Fields(strings.TrimSuffix(line, ";"))
+	name, args := strings.ToLower(fields[0]), fields[1:]
+	switch name {
+	case `\q`, `\quit`, `\exit`:
+		return true, nil
+	case `\d`, `\describe`:
+		if len(args) != 1 {
+			return false, fmt.Errorf("usage: \\describe <table>")
+		}
+		return false, s.execute(ctx, "DESCRIBE "+args[0])
+	case `\format`:
+		if len(args) != 1 || !isFormat(args[0]) {
+			return false, fmt.Errorf("usage: \\format <%v|%v|%v>", formatTable, formatCSV, formatJSON)
+		}
+		s.format = args[0]
+		return false, nil
+	case `\dryrun`:
+		return false, setFlag(&s.dryRun, name, args)
+	case `\stats`:
+		return false, setFlag(&s.stats, name, args)
+	case `\h`, `\help`:
+		fmt.Fprintln(s.out, `statements end with ';', commands:
+  \describe <table>            describe table columns
+  \format <table|csv|json>     set result format
+  \dryrun <on|off>             validate queries without running them
+  \stats <on|off>              show job statistics
+  \quit                        exit`)
+		return false, nil
+	}
+	return false, fmt.Errorf("unknown command: %v, use \\help", name)
+}
+
+func setFlag(flag *bool, name string, args []string) error {
+	if len(args) != 1 || (args[0] != "on" && args[0] != "off") {
+		return fmt.Errorf("usage: %v <on|off>", name)
+	}
+	*flag = args[0] == "on"
+	return nil
+}
+
+// execute executes a single statement
+func (s *shell) execute(ctx context.Context, SQL string) error {
+	switch statementKind(SQL) {
+	case kindIngestion:
+		if s.dryRun {
+			fmt.Fprintln(s.out, "dry run is not supported for ingestion statements")
+			return nil
+		}
+		return s.ingest(ctx, SQL)
+	case kindCommand:
+		rows, err := s.conn.QueryContext(ctx, SQL)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		return s.render(rows)
+	}
+	if s.dryRun {
+		status, err := bigquery.DryRun(ctx, s.conn, SQL)
+		if err != nil {
+			return err
+		}
+		s.printStatistics(status, 0)
+		return nil
+	}
+	return s.query(ctx, SQL)
+}
+
+// query submits query job, waits for its completion and renders results or affected rows
+func (s *shell) query(ctx context.Context, SQL string) error {
+	started := time.Now()
+	job, err := bigquery.Submit(ctx, s.conn, SQL)
+	if err != nil {
+		return err
+	}
+	status, err := job.Wait(ctx)
+	if err != nil {
+		return err
+	}
+	if status.Err != nil {
+		return status.Err
+	}
+	if stats := status.Statistics; stats != nil && stats.Query != nil && (stats.Query.Schema == nil || len(stats.Query.Schema.Fields) == 0) {
+		fmt.Fprintf(s.out, "%v rows affected\n", stats.Query.NumDmlAffectedRows)
+	} else {
+		rows, err := job.Rows(ctx)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		if err = s.render(rows); err != nil {
+			return err
+		}
+	}
+	if s.stats {
+		s.printStatistics(status, time.Since(started))
+	}
+	return nil
+}
+
+// ingest executes LOAD/STREAM/EXPORT TABLE statement, reader IDs referring to local files are registered with file readers
+func (s *shell) ingest(ctx context.Context, SQL string) error {
+	for _, match := range readerOptionExpr.FindAllStringSubmatch(SQL, -1) {
+		readerID := match[1][strings.LastIndex(match[1], ":")+1:]
+		info, err := os.Stat(readerID)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		file, err := os.Open(readerID)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		if err = reader.Register(readerID, file); err != nil {
+			return err
+		}
+		defer reader.Unregister(readerID)
+	}
+	result, err := s.conn.ExecContext(ctx, SQL)
+	if err != nil {
+		return err
+	}
+	affected, _ := result.RowsAffected()
+	fmt.Fprintf(s.out, "%v rows affected\n", affected)
+	return nil
+}
+
+func (s *shell) render(rows *sql.Rows) error {
+	count, err := newRenderer(s.format, s.out).render(rows)
+	if err != nil {
+		return err
+	}
+	if s.format == formatTable {
+		fmt.Fprintf(s.out, "(%v rows)\n", count)
+	}
+	return nil
+}
+
+func (s *shell) printStatistics(status *bigquery.JobStatus, elapsed time.Duration) {
+	stats := status.Statistics
+	if stats == nil {
+		return
+	}
+	var info []string
+	if query := stats.Query; query != nil {
+		info = append(info,
+			fmt.Sprintf("bytes processed: %v", query.TotalBytesProcessed),
+			fmt.Sprintf("bytes billed: %v", query.TotalBytesBilled),
+			fmt.Sprintf("slot ms: %v", query.TotalSlotMs),
+			fmt.Sprintf("cache hit: %v", query.CacheHit))
+	} else {
+		info = append(info, fmt.Sprintf("bytes processed: %v", stats.TotalBytesProcessed))
+	}
+	if elapsed > 0 {
+		info = append(info, fmt.Sprintf("elapsed: %v", elapsed.Round(time.Millisecond)))
+	}
+	fmt.Fprintf(s.out, "-- %v\n", strings.Join(info, ", "))
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitStatements(t *testing.T) {
+	var testCases = []struct {
+		description string
+		script      string
+		expect      []string
+	}{
+		{
+			description: "multiple statements",
+			script:      "SELECT 1;\nSELECT 2;",
+			expect:      []string{"SELECT 1", "SELECT 2"},
+		},
+		{
+			description: "semicolon in quotes, comments and hints",
+			script:      "SELECT ';', \"a;b\" -- c;d\nFROM t; SELECT /*+ {\"Labels\":{\"k\":\"a;b\"}} +*/ 1 # x;y\n",
+			expect:      []string{"SELECT ';', \"a;b\" -- c;d\nFROM t", "SELECT /*+ {\"Labels\":{\"k\":\"a;b\"}} +*/ 1 # x;y"},
+		},
+		{
+			description: "escaped quote and missing terminator",
+			script:      `SELECT 'it\'s;' ; ; SELECT 3`,
+			expect:      []string{`SELECT 'it\'s;'`, "SELECT 3"},
+		},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, splitStatements(testCase.script), testCase.description)
+	}
+}
+
+func TestStatementKind(t *testing.T) {
+	assert.Equal(t, kindIngestion, statementKind("load 'Reader:csv:data.csv' DATA INTO TABLE t"))
+	assert.Equal(t, kindIngestion, statementKind("EXPORT TABLE t TO 'gs://b/t.csv'"))
+	assert.Equal(t, kindQuery, statementKind("EXPORT DATA OPTIONS(uri='gs://b/*.csv') AS SELECT 1"))
+	assert.Equal(t, kindCommand, statementKind("DESCRIBE t"))
+	assert.Equal(t, kindQuery, statementKind("SELECT 1"))
+}
+
+type stubRows struct {
+	columns []string
+	values  [][]interface{}
+	index   int
+}
+
+func (s *stubRows) Columns() ([]string, error) { return s.columns, nil }
+func (s *stubRows) Next() bool                 { s.index++; return s.index <= len(s.values) }
+func (s *stubRows) Err() error                 { return nil }
+func (s *stubRows) Scan(dest ...interface{}) error {
+	for i, value := range s.values[s.index-1] {
+		*dest[i].(*interface{}) = value
+	}
+	return nil
+}
+
+func TestRenderer_Render(t *testing.T) {
+	created := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	values := [][]interface{}{
+		{1, "a,b", created, []string{"x"}},
+		{2, nil, nil, []string{}},
+	}
+	var testCases = []struct {
+		format string
+		expect string
+	}{
+		{
+			format: formatTable,
+			expect: "id  name  created               tags\n--  ----  -------               ----\n1   a,b   2023-01-02T03:04:05Z  [\"x\"]\n2   NULL  NULL                  []\n",
+		},
+		{
+			format: formatCSV,
+			expect: "id,name,created,tags\n1,\"a,b\",2023-01-02T03:04:05Z,\"[\"\"x\"\"]\"\n2,NULL,NULL,[]\n",
+		},
+		{
+			format: formatJSON,
+			expect: "{\"id\":1,\"name\":\"a,b\",\"created\":\"2023-01-02T03:04:05Z\",\"tags\":[\"x\"]}\n{\"id\":2,\"name\":null,\"created\":null,\"tags\":[]}\n",
+		},
+	}
+	for _, testCase := range testCases {
+		out := new(bytes.Buffer)
+		count, err := newRenderer(testCase.format, out).render(&stubRows{columns: []string{"id", "name", "created", "tags"}, values: values})
+		assert.Nil(t, err, testCase.format)
+		assert.Equal(t, 2, count, testCase.format)
+		assert.Equal(t, testCase.expect, out.String(), testCase.format)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+)
+
+const (
+	kindQuery = iota
+	kindIngestion
+	kindCommand
+)
+
+// statementKind returns kind of SQL statement
+func statementKind(SQL string) int {
+	fields := strings.Fields(strings.ToUpper(SQL))
+	if len(fields) == 0 {
+		return kindQuery
+	}
+	switch fields[0] {
+	case "LOAD", "STREAM":
+		return kindIngestion
+	case "EXPORT":
+		if len(fields) > 1 && fields[1] == "TABLE" {
+			return kindIngestion
+		}
+	case "SHOW", "DESCRIBE", "ATTACH":
+		return kindCommand
+	}
+	return kindQuery
+}
+
+// splitStatements splits script into semicolon separated statements,
+// semicolons within quotes, comments and hints are ignored
+func splitStatements(script string) []string {
+	var result []string
+	var begin = 0
+	appendStatement := func(end int) {
+		if SQL := strings.TrimSpace(script[begin:end]); SQL != "" {
+			result = append(result, SQL)
+		}
+		begin = end + 1
+	}
+	for i := 0; i < len(script); i++ {
+		switch c := script[i]; c {
+		case '\'', '"', '`':
+			i = skipQuoted(script, i, c)
+		case '-':
+			if strings.HasPrefix(script[i:], "--") {
+				i = skipUntil(script, i, "\n")
+			}
+		case '#':
+			i = skipUntil(script, i, "\n")
+		case '/':
+			if strings.HasPrefix(script[i:], "/*") {
+				i = skipUntil(script, i+2, "*/") + 1
+			}
+		case ';':
+			appendStatement(i)
+		}
+	}
+	appendStatement(len(script))
+	return result
+}
+
+// skipQuoted returns position of the closing quote
+func skipQuoted(script string, begin int, quote byte) int {
+	for i := begin + 1; i < len(script); i++ {
+		switch script[i] {
+		case '\\':
+			i++
+		case quote:
+			return i
+		}
+	}
+	return len(script) - 1
+}
+
+// skipUntil returns position of the terminator beginning or the last position
+func skipUntil(script string, begin int, terminator string) int {
+	if index := strings.Index(script[begin:], terminator); index != -1 {
+		return begin + index
+	}
+	return len(script) - 1
+}
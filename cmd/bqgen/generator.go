@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/viant/bigquery/internal/schema"
+	"go/format"
+	"google.golang.org/api/bigquery/v2"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// lossyTypes lists BigQuery types sharing Go type with another BigQuery type, their type is kept in bigquery tag
+var lossyTypes = map[string]bool{"DATE": true, "TIME": true, "DATETIME": true, "NUMERIC": true, "GEOGRAPHY": true, "JSON": true}
+
+// stringTypes lists BigQuery types without Go mapping, their values are generated as string (WKT, JSON text)
+var stringTypes = map[string]bool{"GEOGRAPHY": true, "JSON": true}
+
+// generator generates Go struct source from BigQuery table fields
+type generator struct {
+	imports map[string]bool
+	body    bytes.Buffer
+}
+
+// generate generates Go source with typeName struct matching table fields: NULLABLE (including unset mode)
+// fields are pointers, REPEATED fields are slices and RECORD fields are inline structs, bigquery tag keeps column name.
+func generate(pkg, typeName string, fields []*bigquery.TableFieldSchema) ([]byte, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("schema fields were empty")
+	}
+	g := &generator{imports: map[string]bool{}}
+	g.body.WriteString("// " + typeName + " represents table record\n")
+	g.body.WriteString("type " + typeName + " ")
+	if err := g.writeStruct(fields, 0); err != nil {
+		return nil, err
+	}
+	g.body.WriteString("\n")
+	source := new(bytes.Buffer)
+	source.WriteString("// Code generated by bqgen. DO NOT EDIT.\n\n")
+	source.WriteString("package " + pkg + "\n\n")
+	if len(g.imports) > 0 {
+		var imports = make([]string, 0, len(g.imports))
+		for imp := range g.imports {
+			imports = append(imports, imp)
+		}
+		sort.Strings(imports)
+		source.WriteString("import (\n")
+		for _, imp := range imports {
+			source.WriteString("\t\"" + imp + "\"\n")
+		}
+		source.WriteString(")\n\n")
+	}
+	source.Write(g.body.Bytes())
+	result, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated source: %w", err)
+	}
+	return result, nil
+}
+
+func (g *generator) writeStruct(fields []*bigquery.TableFieldSchema, depth int) error {
+	g.body.WriteString("struct {\n")
+	names := map[string]bool{}
+	for _, field := range fields {
+		g.body.WriteString(strings.Repeat("\t", depth+1))
+		g.body.WriteString(uniqueName(fieldName(field.Name), names) + " ")
+		if err := g.writeFieldType(field, depth); err != nil {
+			return err
+		}
+		g.body.WriteString(" `" + fieldTag(field) + "`\n")
+	}
+	g.body.WriteString(strings.Repeat("\t", depth) + "}")
+	return nil
+}
+
+func (g *generator) writeFieldType(field *bigquery.TableFieldSchema, depth int) error {
+	switch field.Mode {
+	case "REPEATED":
+		g.body.WriteString("[]")
+	case "", "NULLABLE":
+		g.body.WriteString("*")
+	}
+	if len(field.Fields) > 0 {
+		return g.writeStruct(field.Fields, depth+1)
+	}
+	if stringTypes[strings.ToUpper(field.Type)] {
+		g.body.WriteString("string")
+		return nil
+	}
+	rType, err := schema.BuildFieldType(&bigquery.TableFieldSchema{Name: field.Name, Type: field.Type, Mode: "REQUIRED"})
+	if err != nil {
+		return err
+	}
+	g.body.WriteString(g.typeName(rType))
+	return nil
+}
+
+// typeName returns Go type name registering required import
+func (g *generator) typeName(rType reflect.Type) string {
+	switch rType.Kind() {
+	case reflect.Ptr:
+		return "*" + g.typeName(rType.Elem())
+	case reflect.Slice:
+		return "[]" + g.typeName(rType.Elem())
+	case reflect.Uint8:
+		return "byte"
+	}
+	if pkgPath := rType.PkgPath(); pkgPath != "" {
+		g.imports[pkgPath] = true
+	}
+	return rType.String()
+}
+
+// fieldTag returns json and bigquery tags of the field
+func fieldTag(field *bigquery.TableFieldSchema) string {
+	jsonTag := field.Name
+	bigqueryTag := field.Name
+	switch field.Mode {
+	case "", "NULLABLE":
+		jsonTag += ",omitempty"
+	case "REQUIRED":
+		bigqueryTag += ",required"
+	}
+	if lossyTypes[strings.ToUpper(field.Type)] {
+		bigqueryTag += ",type=" + strings.ToUpper(field.Type)
+	}
+	return fmt.Sprintf(`json:"%v" bigquery:"%v"`, jsonTag, bigqueryTag)
+}
+
+// initialisms lists common Go initialisms, see https://go.dev/wiki/CodeReviewComments#initialisms
+var initialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true, "EOF": true, "GUID": true,
+	"HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JSON": true, "LHS": true, "QPS": true,
+	"RAM": true, "RHS": true, "RPC": true, "SLA": true, "SMTP": true, "SQL": true, "SSH": true, "TCP": true,
+	"TLS": true, "TTL": true, "UDP": true, "UI": true, "UID": true, "UUID": true, "URI": true, "URL": true,
+	"UTF8": true, "VM": true, "XML": true, "XMPP": true, "XSRF": true, "XSS": true,
+}
+
+// fieldName returns exported Go field name, snake case parts are capitalized and initialisms upper cased i.e. user_id -> UserID,
+// names starting with a digit or without letters are prefixed with X
+func fieldName(name string) string {
+	var result strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		if upper := strings.ToUpper(part); initialisms[upper] {
+			result.WriteString(upper)
+			continue
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		result.WriteString(string(runes))
+	}
+	if runes := []rune(result.String()); len(runes) == 0 || !unicode.IsUpper(runes[0]) {
+		return "X" + result.String()
+	}
+	return result.String()
+}
+
+// uniqueName returns name not used by the struct yet, duplicates get numeric suffix i.e. UserID2
+func uniqueName(name string, used map[string]bool) string {
+	result := name
+	for i := 2; used[result]; i++ {
+		result = name + strconv.Itoa(i)
+	}
+	used[result] = true
+	return result
+}
+
+// structName returns Go type name for a table name i.e. user_events -> UserEvents
+func structName(table string) string {
+	if index := strings.LastIndex(table, "."); index != -1 {
+		table = table[index+1:]
+	}
+	return fieldName(table)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/bigquery/internal/schema"
+	"google.golang.org/api/bigquery/v2"
+)
+
+func TestGenerate(t *testing.T) {
+	fields := []*bigquery.TableFieldSchema{
+		{Name: "id", Type: "INTEGER", Mode: "REQUIRED"},
+		{Name: "user_id", Type: "STRING", Mode: "REQUIRED"},
+		{Name: "name", Type: "STRING", Mode: "NULLABLE"},
+		{Name: "day", Type: "DATE"},
+		{Name: "payload", Type: "BYTES", Mode: "REQUIRED"},
+		{Name: "tags", Type: "STRING", Mode: "REPEATED"},
+		{Name: "address", Type: "RECORD", Mode: "NULLABLE", Fields: []*bigquery.TableFieldSchema{
+			{Name: "city", Type: "STRING", Mode: "REQUIRED"},
+			{Name: "zip", Type: "STRING", Mode: "NULLABLE"},
+		}},
+		{Name: "area", Type: "GEOGRAPHY", Mode: "NULLABLE"},
+		{Name: "attrs", Type: "JSON", Mode: "REQUIRED"},
+		{Name: "User_ID", Type: "STRING", Mode: "REQUIRED"},
+	}
+	expect := "// Code generated by bqgen. DO NOT EDIT.\n\n" +
+		"package model\n\n" +
+		"import (\n\t\"time\"\n)\n\n" +
+		"// UserEvents represents table record\n" +
+		"type UserEvents struct {\n" +
+		"\tID      int        `json:\"id\" bigquery:\"id,required\"`\n" +
+		"\tUserID  string     `json:\"user_id\" bigquery:\"user_id,required\"`\n" +
+		"\tName    *string    `json:\"name,omitempty\" bigquery:\"name\"`\n" +
+		"\tDay     *time.Time `json:\"day,omitempty\" bigquery:\"day,type=DATE\"`\n" +
+		"\tPayload []byte     `json:\"payload\" bigquery:\"payload,required\"`\n" +
+		"\tTags    []string   `json:\"tags\" bigquery:\"tags\"`\n" +
+		"\tAddress *struct {\n" +
+		"\t\tCity string  `json:\"city\" bigquery:\"city,required\"`\n" +
+		"\t\tZip  *string `json:\"zip,omitempty\" bigquery:\"zip\"`\n" +
+		"\t} `json:\"address,omitempty\" bigquery:\"address\"`\n" +
+		"\tArea    *string `json:\"area,omitempty\" bigquery:\"area,type=GEOGRAPHY\"`\n" +
+		"\tAttrs   string  `json:\"attrs\" bigquery:\"attrs,required,type=JSON\"`\n" +
+		"\tUserID2 string  `json:\"User_ID\" bigquery:\"User_ID,required\"`\n" +
+		"}\n"
+	actual, err := generate("model", structName("mydataset.user_events"), fields)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, expect, string(actual))
+
+	//generated record field has to be convertible from driver rows value type
+	type UserEvents struct {
+		Address *struct {
+			City string  `json:"city" bigquery:"city,required"`
+			Zip  *string `json:"zip,omitempty" bigquery:"zip"`
+		} `json:"address,omitempty" bigquery:"address"`
+	}
+	rowType, err := schema.BuildFieldType(fields[6])
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.True(t, rowType.ConvertibleTo(reflect.TypeOf(UserEvents{}.Address).Elem()))
+
+	//generated tags have to keep field modes
+	tableSchema, err := schema.TableSchemaOf(reflect.TypeOf(UserEvents{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "REQUIRED", tableSchema.Fields[0].Fields[0].Mode)
+	assert.Equal(t, "NULLABLE", tableSchema.Fields[0].Fields[1].Mode)
+}
+
+func TestFieldName(t *testing.T) {
+	var testCases = []struct {
+		name   string
+		expect string
+	}{
+		{name: "id", expect: "ID"},
+		{name: "Id", expect: "ID"},
+		{name: "user_id", expect: "UserID"},
+		{name: "api_url", expect: "APIURL"},
+		{name: "createdAt", expect: "CreatedAt"},
+		{name: "_", expect: "X"},
+		{name: "2fa_enabled", expect: "X2faEnabled"},
+		{name: "geo.point", expect: "GeoPoint"},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, fieldName(testCase.name), testCase.name)
+	}
+}
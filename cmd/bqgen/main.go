@@ -0,0 +1,113 @@
+// Command bqgen generates Go struct matching BigQuery table schema.
+//
+// Usage:
+//
+//	bqgen -dsn bigquery://myproject/mydataset -table mytable [-package model] [-type MyTable] [-out mytable.go]
+//	bqgen -schema schema.json -type MyTable [-package model] [-out mytable.go]
+//
+// Local schema file uses either bq CLI format (JSON array of fields) or TableSchema format ({"fields": [...]}).
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	bq "github.com/viant/bigquery"
+	"google.golang.org/api/bigquery/v2"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	dsn := flag.String("dsn", "", "driver DSN i.e. bigquery://myproject/mydataset, used with -table")
+	table := flag.String("table", "", "[project.][dataset.]table to read schema from")
+	schemaFile := flag.String("schema", "", "local JSON schema file")
+	pkg := flag.String("package", "model", "generated package name")
+	typeName := flag.String("type", "", "generated type name, defaults to table name in camel case")
+	out := flag.String("out", "", "output file, defaults to stdout")
+	flag.Parse()
+	if err := run(*dsn, *table, *schemaFile, *pkg, *typeName, *out); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(dsn, table, schemaFile, pkg, typeName, out string) error {
+	var fields []*bigquery.TableFieldSchema
+	var err error
+	switch {
+	case schemaFile != "":
+		fields, err = loadSchemaFile(schemaFile)
+	case dsn != "" && table != "":
+		fields, err = loadTableSchema(dsn, table)
+	default:
+		flag.Usage()
+		return fmt.Errorf("either -schema or -dsn with -table is required")
+	}
+	if err != nil {
+		return err
+	}
+	if typeName == "" {
+		name := table
+		if name == "" {
+			name = strings.TrimSuffix(filepath.Base(schemaFile), filepath.Ext(schemaFile))
+		}
+		typeName = structName(name)
+	}
+	source, err := generate(pkg, typeName, fields)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(source)
+		return err
+	}
+	return os.WriteFile(out, source, 0644)
+}
+
+// loadSchemaFile loads table fields from bq CLI ([...]) or TableSchema ({"fields": [...]}) JSON file
+func loadSchemaFile(location string) ([]*bigquery.TableFieldSchema, error) {
+	data, err := os.ReadFile(location)
+	if err != nil {
+		return nil, err
+	}
+	data = []byte(strings.TrimSpace(string(data)))
+	if len(data) > 0 && data[0] == '[' {
+		var fields []*bigquery.TableFieldSchema
+		if err = json.Unmarshal(data, &fields); err != nil {
+			return nil, fmt.Errorf("invalid schema file: %v, %w", location, err)
+		}
+		return fields, nil
+	}
+	tableSchema := &bigquery.TableSchema{}
+	if err = json.Unmarshal(data, tableSchema); err != nil {
+		return nil, fmt.Errorf("invalid schema file: %v, %w", location, err)
+	}
+	return tableSchema.Fields, nil
+}
+
+// loadTableSchema loads table fields with the driver metadata API
+func loadTableSchema(dsn, table string) ([]*bigquery.TableFieldSchema, error) {
+	db, err := sql.Open("bigquery", dsn)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	aTable, err := bq.GetTable(ctx, conn, table)
+	if err != nil {
+		return nil, err
+	}
+	if aTable.Schema == nil {
+		return nil, fmt.Errorf("table %v has no schema", table)
+	}
+	return aTable.Schema.Fields, nil
+}
@@ -0,0 +1,125 @@
+package bigquery
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"github.com/viant/bigquery/internal/command"
+	"github.com/viant/bigquery/internal/exec"
+	"github.com/viant/bigquery/internal/logging"
+	"github.com/viant/bigquery/internal/trace"
+	"google.golang.org/api/bigquery/v2"
+	"strings"
+)
+
+// commandStatement represents driver pseudo statement i.e. ATTACH JOB 'project:location.jobID', SHOW TABLES or DESCRIBE table
+type commandStatement struct {
+	projectID string
+	location  string
+	command   *command.Command
+	conn      *connection
+}
+
+// Close closes statement
+func (s *commandStatement) Close() error {
+	return nil
+}
+
+// NumInput returns number of inputs
+func (s *commandStatement) NumInput() int {
+	return 0
+}
+
+// Exec waits for the attached job completion and returns affected rows
+func (s *commandStatement) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), nil)
+}
+
+// ExecContext waits for the attached job completion and returns affected rows
+func (s *commandStatement) ExecContext(ctx context.Context, args []driver.NamedValue) (_ driver.Result, err error) {
+	ctx, span := trace.Start(ctx, "bigquery.Exec", trace.JobKindKey.String(string(s.command.Kind)))
+	defer func() { trace.End(span, err) }()
+	if s.command.Kind != command.KindAttachJob {
+		return nil, fmt.Errorf("unsupported exec statement: %v, use query instead", s.command.Kind)
+	}
+	job, err := s.waitForJob(ctx)
+	if err != nil {
+		return nil, err
+	}
+	trace.SetJob(span, job)
+	res := result{}
+	if stats := job.Statistics; stats != nil && stats.Query != nil {
+		res.totalRows = stats.Query.NumDmlAffectedRows
+	}
+	return &res, nil
+}
+
+// Query returns the attached job rows
+func (s *commandStatement) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), nil)
+}
+
+// QueryContext returns the attached job or metadata rows
+func (s *commandStatement) QueryContext(ctx context.Context, args []driver.NamedValue) (_ driver.Rows, err error) {
+	queryCtx, span := trace.Start(ctx, "bigquery.Query", trace.JobKindKey.String(string(s.command.Kind)))
+	defer func() { trace.End(span, err) }()
+	switch s.command.Kind {
+	case command.KindShowDatasets:
+		datasets, err := s.conn.listDatasets(queryCtx, s.command.Target)
+		if err != nil {
+			return nil, err
+		}
+		return newCommandRows(showDatasetsSchema, datasetValues(datasets))
+	case command.KindShowTables:
+		tables, err := s.conn.listTables(queryCtx, s.command.Target)
+		if err != nil {
+			return nil, err
+		}
+		return newCommandRows(showTablesSchema, tableValues(tables))
+	case command.KindDescribe:
+		table, err := s.conn.getTable(queryCtx, s.command.Target)
+		if err != nil {
+			return nil, err
+		}
+		var fields []*bigquery.TableFieldSchema
+		if table.Schema != nil {
+			fields = table.Schema.Fields
+		}
+		return newCommandRows(describeSchema, columnValues(fields))
+	}
+	job, err := s.waitForJob(queryCtx)
+	if err != nil {
+		return nil, err
+	}
+	trace.SetJob(span, job)
+	ref := s.command.Job
+	service, err := s.conn.contextService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newRows(ctx, service, ref.ProjectID, ref.Location, job)
+}
+
+func (s *commandStatement) waitForJob(ctx context.Context) (*bigquery.Job, error) {
+	ref := s.command.Job
+	ref.Init(s.projectID, s.location)
+	service, err := s.conn.contextService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	job, err := exec.WaitForJobCompletion(logging.WithDefault(ctx, s.conn.logger), service, ref.ProjectID, ref.Location, ref.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach job: %v:%v.%v, %w", ref.ProjectID, ref.Location, ref.ID, err)
+	}
+	return job, nil
+}
+
+// ResultsOf returns rows of an existing query job, jobID uses [project:][location.]jobID format,
+// unspecified project and location default to the connection ones.
+func ResultsOf(ctx context.Context, conn *sql.Conn, jobID string) (*sql.Rows, error) {
+	if strings.ContainsAny(jobID, `'\`) {
+		return nil, fmt.Errorf("invalid job ID: %q", jobID)
+	}
+	return conn.QueryContext(ctx, "ATTACH JOB '"+jobID+"'")
+}
@@ -0,0 +1,135 @@
+package bigquery
+
+import (
+	"database/sql/driver"
+	"github.com/viant/bigquery/internal/schema"
+	"google.golang.org/api/bigquery/v2"
+	"io"
+	"reflect"
+)
+
+var (
+	showDatasetsSchema = &bigquery.TableSchema{Fields: []*bigquery.TableFieldSchema{
+		{Name: "project_id", Type: "STRING", Mode: "REQUIRED"},
+		{Name: "dataset_id", Type: "STRING", Mode: "REQUIRED"},
+		{Name: "location", Type: "STRING", Mode: "REQUIRED"},
+		{Name: "friendly_name", Type: "STRING", Mode: "REQUIRED"},
+	}}
+	showTablesSchema = &bigquery.TableSchema{Fields: []*bigquery.TableFieldSchema{
+		{Name: "project_id", Type: "STRING", Mode: "REQUIRED"},
+		{Name: "dataset_id", Type: "STRING", Mode: "REQUIRED"},
+		{Name: "table_id", Type: "STRING", Mode: "REQUIRED"},
+		{Name: "table_type", Type: "STRING", Mode: "REQUIRED"},
+		{Name: "created", Type: "TIMESTAMP", Mode: "NULLABLE"},
+	}}
+	describeSchema = &bigquery.TableSchema{Fields: []*bigquery.TableFieldSchema{
+		{Name: "ordinal_position", Type: "INTEGER", Mode: "REQUIRED"},
+		{Name: "column_name", Type: "STRING", Mode: "REQUIRED"},
+		{Name: "data_type", Type: "STRING", Mode: "REQUIRED"},
+		{Name: "mode", Type: "STRING", Mode: "REQUIRED"},
+		{Name: "is_nullable", Type: "STRING", Mode: "REQUIRED"},
+		{Name: "description", Type: "STRING", Mode: "REQUIRED"},
+	}}
+)
+
+// commandRows represents in memory rows of driver pseudo statements
+type commandRows struct {
+	schema  *bigquery.TableSchema
+	types   []reflect.Type
+	columns []string
+	values  [][]driver.Value
+	index   int
+}
+
+// Columns returns columns
+func (r *commandRows) Columns() []string {
+	return r.columns
+}
+
+// Close closes rows
+func (r *commandRows) Close() error {
+	r.values = nil
+	return nil
+}
+
+// Next moves to next row
+func (r *commandRows) Next(dest []driver.Value) error {
+	if r.index >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.index])
+	r.index++
+	return nil
+}
+
+// ColumnTypeScanType returns column scan type
+func (r *commandRows) ColumnTypeScanType(index int) reflect.Type {
+	return r.types[index]
+}
+
+// ColumnTypeDatabaseTypeName returns column database type name
+func (r *commandRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.schema.Fields[index].Type
+}
+
+// ColumnTypeNullable returns if column is nullable
+func (r *commandRows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	return r.schema.Fields[index].Mode == "NULLABLE", true
+}
+
+func newCommandRows(tableSchema *bigquery.TableSchema, values [][]driver.Value) (*commandRows, error) {
+	types, err := schema.BuildSchemaTypes(tableSchema)
+	if err != nil {
+		return nil, err
+	}
+	result := &commandRows{schema: tableSchema, types: types, values: values}
+	for _, field := range tableSchema.Fields {
+		result.columns = append(result.columns, field.Name)
+	}
+	return result, nil
+}
+
+func datasetValues(datasets []*Dataset) [][]driver.Value {
+	var result = make([][]driver.Value, len(datasets))
+	for i, dataset := range datasets {
+		result[i] = []driver.Value{dataset.ProjectID, dataset.ID, dataset.Location, dataset.FriendlyName}
+	}
+	return result
+}
+
+func tableValues(tables []*Table) [][]driver.Value {
+	var result = make([][]driver.Value, len(tables))
+	for i, table := range tables {
+		var created driver.Value
+		if !table.Created.IsZero() {
+			created = table.Created
+		}
+		result[i] = []driver.Value{table.ProjectID, table.DatasetID, table.ID, table.Type, created}
+	}
+	return result
+}
+
+// columnValues returns column rows, nested RECORD fields are listed with dotted column names,
+// data types are reported with schema.TypeName, i.e. ARRAY<STRING>
+func columnValues(fields []*bigquery.TableFieldSchema) [][]driver.Value {
+	var result [][]driver.Value
+	appendColumnValues(&result, "", fields)
+	return result
+}
+
+func appendColumnValues(result *[][]driver.Value, prefix string, fields []*bigquery.TableFieldSchema) {
+	for _, field := range fields {
+		mode := field.Mode
+		if mode == "" {
+			mode = "NULLABLE"
+		}
+		isNullable := "NO"
+		if mode == "NULLABLE" {
+			isNullable = "YES"
+		}
+		*result = append(*result, []driver.Value{int64(len(*result) + 1), prefix + field.Name, schema.TypeName(field), mode, isNullable, field.Description})
+		if len(field.Fields) > 0 {
+			appendColumnValues(result, prefix+field.Name+".", field.Fields)
+		}
+	}
+}
@@ -3,12 +3,17 @@ package bigquery
 import (
 	"context"
 	"database/sql/driver"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/viant/bigquery/internal/command"
+	"github.com/viant/bigquery/internal/exec"
 	"github.com/viant/bigquery/internal/hint"
 	"github.com/viant/bigquery/internal/ingestion"
+	"github.com/viant/bigquery/internal/label"
+	"github.com/viant/bigquery/internal/logging"
+	"github.com/viant/bigquery/internal/trace"
 	"google.golang.org/api/bigquery/v2"
 )
 
@@ -17,6 +22,22 @@ type connection struct {
 	projectID string
 	ctx       context.Context
 	service   *bigquery.Service
+	shared    *sharedService
+	logger    *logging.Logger
+}
+
+// contextService returns service of the credentials carried by ctx, see WithTokenSource
+func (c *connection) contextService(ctx context.Context) (*bigquery.Service, error) {
+	return c.shared.contextService(ctx, c.service)
+}
+
+// ingestionService returns ingestion service of the credentials carried by ctx
+func (c *connection) ingestionService(ctx context.Context) (*ingestion.Service, error) {
+	service, err := c.contextService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ingestion.NewService(service, c.projectID, c.cfg.DatasetID, c.cfg.Location, ingestion.WithLabels(c.cfg.jobLabels())), nil
 }
 
 // Prepare returns a prepared statement, bound to this connection.
@@ -26,18 +47,28 @@ func (c *connection) Prepare(query string) (driver.Stmt, error) {
 
 func (c *connection) isIngestion(SQL string) bool {
 	normalizedSQL := strings.ToUpper(strings.TrimSpace(SQL))
+	if strings.HasPrefix(normalizedSQL, string(ingestion.KindExport)) { //EXPORT DATA is a native statement
+		fields := strings.Fields(normalizedSQL)
+		return len(fields) > 1 && fields[0] == string(ingestion.KindExport) && fields[1] == "TABLE"
+	}
 	return strings.HasPrefix(normalizedSQL, string(ingestion.KindLoad)) || strings.HasPrefix(normalizedSQL, string(ingestion.KindStream))
 }
 
 // PrepareContext returns a prepared statement, bound to this connection.
-func (c *connection) PrepareContext(ctx context.Context, SQL string) (driver.Stmt, error) {
+func (c *connection) PrepareContext(ctx context.Context, SQL string) (_ driver.Stmt, err error) {
+	_, span := trace.Start(ctx, "bigquery.Prepare", trace.ProjectKey.String(c.projectID), trace.LocationKey.String(c.cfg.Location))
+	defer func() { trace.End(span, err) }()
 
 	if c.isIngestion(SQL) {
-		return &ingestionStatement{
-			service: ingestion.NewService(c.service, c.projectID, c.cfg.DatasetID, c.cfg.Location),
-			ctx:     ctx,
-			SQL:     SQL,
-		}, nil
+		return &ingestionStatement{conn: c, ctx: ctx, SQL: SQL}, nil
+	}
+
+	if command.Is(SQL) {
+		aCommand, err := command.Parse(SQL)
+		if err != nil {
+			return nil, err
+		}
+		return &commandStatement{projectID: c.projectID, location: c.cfg.Location, command: aCommand, conn: c}, nil
 	}
 
 	jobConfiguration, err := c.jobConfiguration(SQL)
@@ -45,7 +76,7 @@ func (c *connection) PrepareContext(ctx context.Context, SQL string) (driver.Stm
 		return nil, err
 	}
 
-	stmt := &Statement{job: jobConfiguration, service: c.service, projectID: c.projectID, location: c.cfg.Location}
+	stmt := &Statement{job: jobConfiguration, service: c.service, projectID: c.projectID, location: c.cfg.Location, labels: jobConfiguration.Configuration.Labels, logger: c.logger, shared: c.shared}
 	stmt.checkQueryParameters()
 	return stmt, nil
 }
@@ -56,15 +87,18 @@ func (c *connection) jobConfiguration(query string) (*bigquery.Job, error) {
 	}
 	useLegacy := false
 	configQuery := &bigquery.JobConfigurationQuery{UseLegacySql: &useLegacy}
+	labels := c.cfg.jobLabels()
 
-	if aHint := hint.Extract(query); aHint != "" {
+	if blocks := hint.Find(query); len(blocks) > 0 {
 		userHint := &queryHint{
 			JobConfigurationQuery: bigquery.JobConfigurationQuery{
 				UseLegacySql: &useLegacy,
 			},
 		}
-		if err := json.Unmarshal([]byte(aHint), &userHint); err != nil {
-			return nil, fmt.Errorf("invalid aHint %v, %w", aHint, err)
+		for _, block := range blocks {
+			if err := hint.Decode(block.Text, userHint); err != nil {
+				return nil, fmt.Errorf("invalid hint %v, %w", block.Text, err)
+			}
 		}
 		if userHint.ExpandDSN {
 			if count := strings.Count(query, dsnProjectID); count > 0 {
@@ -77,7 +111,14 @@ func (c *connection) jobConfiguration(query string) (*bigquery.Job, error) {
 				query = strings.Replace(query, dsnLocation, c.cfg.Location, count)
 			}
 		}
+		if err := userHint.initDestination(c.projectID, c.cfg.DatasetID); err != nil {
+			return nil, err
+		}
+		if err := userHint.applyJobOptions(job); err != nil {
+			return nil, err
+		}
 		configQuery = &userHint.JobConfigurationQuery
+		labels = label.Merge(labels, userHint.Labels)
 	}
 
 	configQuery.Query = query
@@ -92,14 +133,44 @@ func (c *connection) jobConfiguration(query string) (*bigquery.Job, error) {
 		}
 	}
 	job.Configuration.Query = configQuery
+	job.Configuration.Labels = labels
 	if c.cfg.Reservation != "" {
 		job.Configuration.Reservation = c.cfg.Reservation
 	}
 	return job, nil
 }
 
-// Ping pings server
-func (c *connection) Ping(ctx context.Context) error {
+// Ping checks credentials, project and dataset with datasets.get of the default dataset or jobs.list without default dataset,
+// context credentials (see WithTokenSource) are checked instead of the DSN ones when present, failures are classified as ErrUnauthenticated, ErrPermissionDenied or ErrNotFound
+func (c *connection) Ping(ctx context.Context) (err error) {
+	if c.service == nil {
+		return driver.ErrBadConn
+	}
+	ctx, span := trace.Start(logging.WithDefault(ctx, c.logger), "bigquery.Ping", trace.ProjectKey.String(c.projectID))
+	defer func() { trace.End(span, err) }()
+	target := c.projectID
+	if c.cfg.DatasetID != "" {
+		target += "." + c.cfg.DatasetID
+	}
+	service, err := c.contextService(ctx)
+	if err != nil {
+		return err
+	}
+	err = exec.RunWithRetries(ctx, func() error {
+		if c.cfg.DatasetID != "" {
+			_, err := service.Datasets.Get(c.projectID, c.cfg.DatasetID).Fields("id").Context(ctx).Do()
+			return err
+		}
+		_, err := service.Jobs.List(c.projectID).MaxResults(1).Fields("jobs/id").Context(ctx).Do()
+		return err
+	}, 3)
+	if err != nil {
+		err = classifyError(err)
+		if errors.Is(err, ErrUnauthenticated) && c.shared != nil && service == c.service {
+			c.shared.stale.Store(true)
+		}
+		return fmt.Errorf("failed to ping %v, %w", target, err)
+	}
 	return nil
 }
 
@@ -119,12 +190,19 @@ func (c *connection) Close() error {
 	return nil
 }
 
-// ResetSession resets session
+// ResetSession resets session, invalid connections are reported as driver.ErrBadConn
 func (c *connection) ResetSession(ctx context.Context) error {
+	if !c.IsValid() {
+		return driver.ErrBadConn
+	}
 	return nil
 }
 
-// IsValid check is connection is valid
+// IsValid returns false for closed connections and connections whose shared service observed
+// an authentication failure, i.e. expired token that could not be refreshed, or was replaced after credentials rotation
 func (c *connection) IsValid() bool {
-	return true
+	if c.service == nil {
+		return false
+	}
+	return c.shared == nil || !c.shared.stale.Load()
 }
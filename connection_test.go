@@ -1,9 +1,16 @@
 package bigquery
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
 )
 
 func TestJobConfiguration_Reservation(t *testing.T) {
@@ -75,3 +82,288 @@ func TestJobConfiguration_Reservation(t *testing.T) {
 		})
 	}
 }
+
+func TestJobConfiguration_Labels(t *testing.T) {
+	var testCases = []struct {
+		description string
+		cfg         *Config
+		query       string
+		expect      map[string]string
+	}{
+		{
+			description: "default app label",
+			cfg:         &Config{ProjectID: "myproject", App: defaultApp},
+			query:       "SELECT 1",
+			expect:      map[string]string{"app": defaultApp},
+		},
+		{
+			description: "DSN labels",
+			cfg:         &Config{ProjectID: "myproject", App: defaultApp, Labels: map[string]string{"team": "ads"}},
+			query:       "SELECT 1",
+			expect:      map[string]string{"app": defaultApp, "team": "ads"},
+		},
+		{
+			description: "hint labels override DSN labels",
+			cfg:         &Config{ProjectID: "myproject", App: defaultApp, Labels: map[string]string{"team": "ads", "service": "api"}},
+			query:       `SELECT /*+ {"Labels": {"service": "batch"}} +*/ 1`,
+			expect:      map[string]string{"app": defaultApp, "team": "ads", "service": "batch"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			conn := &connection{cfg: tc.cfg, projectID: tc.cfg.ProjectID}
+			job, err := conn.jobConfiguration(tc.query)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tc.expect, job.Configuration.Labels)
+		})
+	}
+}
+
+func TestJobConfiguration_Destination(t *testing.T) {
+	var testCases = []struct {
+		description string
+		query       string
+		expect      *bigquery.JobConfigurationQuery
+		hasError    bool
+	}{
+		{
+			description: "destination with defaults",
+			query:       `SELECT /*+ {"Destination": "mytable", "WriteDisposition": "truncate"} +*/ 1`,
+			expect: &bigquery.JobConfigurationQuery{
+				DestinationTable: &bigquery.TableReference{ProjectId: "myproject", DatasetId: "mydataset", TableId: "mytable"},
+				WriteDisposition: "WRITE_TRUNCATE",
+			},
+		},
+		{
+			description: "destination with partitioning and clustering",
+			query:       `SELECT /*+ {"Destination": "other.set.mytable", "CreateDisposition": "if_needed", "Partition": "day:created", "ClusterBy": ["id"]} +*/ 1`,
+			expect: &bigquery.JobConfigurationQuery{
+				DestinationTable:  &bigquery.TableReference{ProjectId: "other", DatasetId: "set", TableId: "mytable"},
+				CreateDisposition: "CREATE_IF_NEEDED",
+				TimePartitioning:  &bigquery.TimePartitioning{Type: "DAY", Field: "created"},
+				Clustering:        &bigquery.Clustering{Fields: []string{"id"}},
+			},
+		},
+		{
+			description: "invalid destination",
+			query:       `SELECT /*+ {"Destination": "set.my table"} +*/ 1`,
+			hasError:    true,
+		},
+		{
+			description: "invalid write disposition",
+			query:       `SELECT /*+ {"Destination": "mytable", "WriteDisposition": "WRITE_ALL"} +*/ 1`,
+			hasError:    true,
+		},
+		{
+			description: "raw write disposition without destination",
+			query:       `SELECT /*+ {"WriteDisposition": "WRITE_APPEND"} +*/ 1`,
+			expect:      &bigquery.JobConfigurationQuery{WriteDisposition: "WRITE_APPEND"},
+		},
+		{
+			description: "raw destination with lower case partitioning type",
+			query:       `SELECT /*+ {"destinationTable": {"projectId": "p", "datasetId": "d", "tableId": "t"}, "createDisposition": "CREATE_NEVER", "timePartitioning": {"type": "day"}} +*/ 1`,
+			expect: &bigquery.JobConfigurationQuery{
+				DestinationTable:  &bigquery.TableReference{ProjectId: "p", DatasetId: "d", TableId: "t"},
+				CreateDisposition: "CREATE_NEVER",
+				TimePartitioning:  &bigquery.TimePartitioning{Type: "DAY"},
+			},
+		},
+		{
+			description: "partition without destination",
+			query:       `SELECT /*+ {"Partition": "DAY"} +*/ 1`,
+			hasError:    true,
+		},
+		{
+			description: "invalid partitioning type",
+			query:       `SELECT /*+ {"Destination": "mytable", "Partition": "WEEK"} +*/ 1`,
+			hasError:    true,
+		},
+		{
+			description: "too many clustering fields",
+			query:       `SELECT /*+ {"Destination": "mytable", "ClusterBy": ["a", "b", "c", "d", "e"]} +*/ 1`,
+			hasError:    true,
+		},
+		{
+			description: "allow large results with standard SQL",
+			query:       `SELECT /*+ {"Destination": "mytable", "AllowLargeResults": true} +*/ 1`,
+			hasError:    true,
+		},
+		{
+			description: "allow large results with legacy SQL",
+			query:       `SELECT /*+ {"Destination": "mytable", "AllowLargeResults": true, "UseLegacySql": true} +*/ 1`,
+			expect: &bigquery.JobConfigurationQuery{
+				DestinationTable:  &bigquery.TableReference{ProjectId: "myproject", DatasetId: "mydataset", TableId: "mytable"},
+				AllowLargeResults: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			conn := &connection{cfg: &Config{ProjectID: "myproject", DatasetID: "mydataset"}, projectID: "myproject"}
+			job, err := conn.jobConfiguration(tc.query)
+			if tc.hasError {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			actual := job.Configuration.Query
+			assert.Equal(t, tc.expect.DestinationTable, actual.DestinationTable)
+			assert.Equal(t, tc.expect.WriteDisposition, actual.WriteDisposition)
+			assert.Equal(t, tc.expect.CreateDisposition, actual.CreateDisposition)
+			assert.Equal(t, tc.expect.TimePartitioning, actual.TimePartitioning)
+			assert.Equal(t, tc.expect.Clustering, actual.Clustering)
+			assert.Equal(t, tc.expect.AllowLargeResults, actual.AllowLargeResults)
+		})
+	}
+}
+
+func TestJobConfiguration_Hint(t *testing.T) {
+	var testCases = []struct {
+		description    string
+		query          string
+		expectLabels   map[string]string
+		expectMaxBytes int64
+		expectTimeout  int64
+		expectDryRun   bool
+		hasError       bool
+	}{
+		{
+			description:    "compact hint",
+			query:          `SELECT /*+ Labels=team:ads,env:prod MaxBytesBilled=1000 Timeout=30s DryRun=true +*/ 1`,
+			expectLabels:   map[string]string{"app": defaultApp, "team": "ads", "env": "prod"},
+			expectMaxBytes: 1000,
+			expectTimeout:  30000,
+			expectDryRun:   true,
+		},
+		{
+			description:    "typed hint",
+			query:          Hint{Labels: map[string]string{"team": "ads"}, MaxBytesBilled: 2000, Timeout: time.Minute}.String() + " SELECT 1",
+			expectLabels:   map[string]string{"app": defaultApp, "team": "ads"},
+			expectMaxBytes: 2000,
+			expectTimeout:  60000,
+		},
+		{
+			description:    "multiple hints are merged",
+			query:          `SELECT /*+ {"Labels": {"team": "ads"}} +*/ 1 /*+ maxBytesBilled=10 +*/`,
+			expectLabels:   map[string]string{"app": defaultApp, "team": "ads"},
+			expectMaxBytes: 10,
+		},
+		{
+			description:  "hint like text in string literal is ignored",
+			query:        `SELECT '/*+ {"Labels": {"team": "ads"}} +*/' AS x`,
+			expectLabels: map[string]string{"app": defaultApp},
+		},
+		{
+			description: "unknown compact key",
+			query:       `SELECT /*+ MaxBytes=10 +*/ 1`,
+			hasError:    true,
+		},
+		{
+			description: "unknown JSON key",
+			query:       `SELECT /*+ {"Lables": {"team": "ads"}} +*/ 1`,
+			hasError:    true,
+		},
+		{
+			description: "invalid timeout",
+			query:       `SELECT /*+ Timeout=soon +*/ 1`,
+			hasError:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			conn := &connection{cfg: &Config{ProjectID: "myproject", App: defaultApp}, projectID: "myproject"}
+			job, err := conn.jobConfiguration(tc.query)
+			if tc.hasError {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tc.expectLabels, job.Configuration.Labels)
+			assert.Equal(t, tc.expectMaxBytes, job.Configuration.Query.MaximumBytesBilled)
+			assert.Equal(t, tc.expectTimeout, job.Configuration.JobTimeoutMs)
+			assert.Equal(t, tc.expectDryRun, job.Configuration.DryRun)
+		})
+	}
+}
+
+func TestHint_String(t *testing.T) {
+	var testCases = []struct {
+		description string
+		hint        Hint
+		expect      string
+	}{
+		{
+			description: "empty hint",
+		},
+		{
+			description: "all options",
+			hint:        Hint{Labels: map[string]string{"team": "ads", "env": "prod"}, MaxBytesBilled: 100, Timeout: 90 * time.Second, DryRun: true, Priority: PriorityBatch, Destination: "ds.my table"},
+			expect:      `/*+ Labels=env:prod,team:ads MaxBytesBilled=100 Timeout=1m30s DryRun=true Priority=BATCH Destination="ds.my table" +*/`,
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expect, tc.hint.String(), tc.description)
+	}
+}
+
+func TestConnection_Ping(t *testing.T) {
+	var testCases = []struct {
+		description string
+		datasetID   string
+		status      int
+		reason      string
+		expectPath  string
+		expectErr   error
+		expectValid bool
+	}{
+		{description: "dataset exists", datasetID: "ds", status: http.StatusOK, expectPath: "/projects/p/datasets/ds", expectValid: true},
+		{description: "jobs list without dataset", status: http.StatusOK, expectPath: "/projects/p/jobs", expectValid: true},
+		{description: "missing dataset", datasetID: "ds", status: http.StatusNotFound, reason: "notFound", expectErr: ErrNotFound, expectValid: true},
+		{description: "permission denied", datasetID: "ds", status: http.StatusForbidden, reason: "accessDenied", expectErr: ErrPermissionDenied, expectValid: true},
+		{description: "invalid credentials", datasetID: "ds", status: http.StatusUnauthorized, reason: "authError", expectErr: ErrUnauthenticated},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var path string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				if tc.status != http.StatusOK {
+					_, _ = fmt.Fprintf(w, `{"error": {"code": %d, "message": "%v", "errors": [{"reason": "%v"}]}}`, tc.status, tc.reason, tc.reason)
+					return
+				}
+				_, _ = w.Write([]byte("{}"))
+			}))
+			defer server.Close()
+			aConnector := &connector{
+				cfg:     &Config{ProjectID: "p", DatasetID: tc.datasetID},
+				options: []option.ClientOption{option.WithHTTPClient(server.Client()), option.WithEndpoint(server.URL)},
+			}
+			conn, err := aConnector.Connect(context.Background())
+			if !assert.NoError(t, err) {
+				return
+			}
+			c := conn.(*connection)
+			err = c.Ping(context.Background())
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectPath, path)
+			}
+			assert.Equal(t, tc.expectValid, c.IsValid())
+			assert.NoError(t, c.Close())
+			assert.False(t, c.IsValid())
+		})
+	}
+}
@@ -3,19 +3,24 @@ package bigquery
 import (
 	"context"
 	"database/sql/driver"
+	"github.com/viant/bigquery/internal/logging"
 	"github.com/viant/scy/auth/gcp"
 	"github.com/viant/scy/auth/gcp/client"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/bigquery/v2"
 	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
 	"reflect"
 	"strings"
+	"sync"
 )
 
 type connector struct {
 	cfg     *Config
 	options []option.ClientOption
+	mux     sync.Mutex
+	shared  *sharedService
 }
 
 var globalOptions []option.ClientOption
@@ -27,12 +32,70 @@ func SetOptions(opts ...option.ClientOption) {
 
 // Connect connects to database
 func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
-	options := c.cfg.options()
+	shared, err := c.sharedService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &connection{
+		ctx:       ctx,
+		service:   shared.service,
+		shared:    shared,
+		cfg:       c.cfg,
+		projectID: c.cfg.ProjectID,
+		logger:    logging.New(c.cfg.Logger, c.cfg.LogRedaction),
+	}, nil
+}
+
+// sharedService returns service shared by connector connections, the service is rebuilt when
+// credentials files change or an authentication failure was observed, the latter reloads secrets first
+func (c *connector) sharedService(ctx context.Context) (*sharedService, error) {
+	version := c.cfg.credentialsVersion()
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	previous := c.shared
+	if previous != nil && previous.version == version && !previous.stale.Load() {
+		return previous, nil
+	}
+	if previous != nil && previous.stale.Load() {
+		if err := c.cfg.reloadSecrets(); err != nil {
+			return nil, err
+		}
+	}
+	//shared service outlives the connect context, thus only its values are used
+	shared, err := c.newSharedService(context.WithoutCancel(ctx))
+	if err != nil {
+		return nil, err
+	}
+	shared.version = version
+	if previous != nil {
+		//invalidates connections using the previous service
+		previous.stale.Store(true)
+		if previous.client != nil {
+			previous.client.CloseIdleConnections()
+		}
+	}
+	c.shared = shared
+	return shared, nil
+}
+
+func (c *connector) newSharedService(ctx context.Context) (*sharedService, error) {
+	options := c.cfg.serviceOptions()
+	credOptions := c.cfg.credentialOptions()
+	shared := &sharedService{
+		base:       c.cfg.transport(),
+		options:    options,
+		scopes:     c.cfg.scopes(),
+		identities: newIdentityCache(c.cfg.MaxIdentities),
+	}
 
 	//If both OAuth2 token and config URLs are provided, build token source and use it.
 	tokenSourceProvided := false
 	if c.cfg.OAuth2ConfigURL != "" && c.cfg.OAuth2TokenURL != "" {
-		helper := NewOAuth2Manager()
+		var managerOptions []OAuth2Option
+		if key, ok := c.cfg.oauth2TokenKey(); ok {
+			managerOptions = append(managerOptions, WithTokenKey(key))
+		}
+		helper := NewOAuth2Manager(managerOptions...)
 		oauthCfg, err := helper.ConfigFromURL(ctx, c.cfg.OAuth2ConfigURL)
 		if err != nil {
 			return nil, err
@@ -41,14 +104,25 @@ func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
 		if err != nil {
 			return nil, err
 		}
-		src, err := helper.TokenSource(ctx, oauthCfg, oauthToken)
+		src, err := helper.PersistingTokenSource(logging.WithDefault(ctx, logging.New(c.cfg.Logger, c.cfg.LogRedaction)), oauthCfg, oauthToken, c.cfg.OAuth2TokenURL)
 		if err != nil {
 			return nil, err
 		}
-		options = append(options, option.WithTokenSource(src))
+		credOptions = append(credOptions, option.WithTokenSource(src))
 		tokenSourceProvided = true
 	}
 
+	//Impersonation uses DSN or OAuth2 credentials, or application default credentials if none is configured
+	if c.cfg.Impersonate != "" {
+		src, err := c.cfg.impersonatedTokenSource(ctx, credOptions...)
+		if err != nil {
+			return nil, err
+		}
+		credOptions = []option.ClientOption{option.WithTokenSource(src)}
+		tokenSourceProvided = true
+	}
+	options = append(options, credOptions...)
+
 	if len(c.options) > 0 {
 		options = append(options, c.options...)
 	} else if len(globalOptions) > 0 {
@@ -64,20 +138,24 @@ func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
 		gcpService := gcp.New(client.NewGCloud())
 		httpClient, err := gcpService.AuthClient(context.Background(), append(gcp.Scopes, "https://www.googleapis.com/auth/bigquery")...)
 		if err == nil && httpClient != nil {
-			options = append(options, option.WithHTTPClient(httpClient))
+			shared.client = shared.observe(httpClient.Transport)
+			options = append(options, option.WithHTTPClient(shared.client))
 		}
 	}
 
-	service, err := bigquery.NewService(ctx, options...)
-	if err != nil {
+	if !hasOption(options, "option.withHTTPClient") {
+		transport, err := htransport.NewTransport(ctx, shared.base, options...)
+		if err != nil {
+			return nil, err
+		}
+		shared.client = shared.observe(transport)
+		options = append(filterOptions(options, "option.withEndpoint"), option.WithHTTPClient(shared.client))
+	}
+	var err error
+	if shared.service, err = bigquery.NewService(ctx, options...); err != nil {
 		return nil, err
 	}
-	return &connection{
-		ctx:       ctx,
-		service:   service,
-		cfg:       c.cfg,
-		projectID: c.cfg.ProjectID,
-	}, nil
+	return shared, nil
 }
 
 func isAuth(options []option.ClientOption) bool {
@@ -103,6 +181,22 @@ func isAuth(options []option.ClientOption) bool {
 	return false
 }
 
+// hasOption returns true if options contain option of the type name, i.e. option.withHTTPClient
+func hasOption(options []option.ClientOption, typeName string) bool {
+	return len(filterOptions(options, typeName)) > 0
+}
+
+// filterOptions returns options of the type name
+func filterOptions(options []option.ClientOption, typeName string) []option.ClientOption {
+	var result []option.ClientOption
+	for _, opt := range options {
+		if reflect.TypeOf(opt).String() == typeName {
+			result = append(result, opt)
+		}
+	}
+	return result
+}
+
 // Driver returns a driver
 func (c *connector) Driver() driver.Driver {
 	return &Driver{}
@@ -0,0 +1,81 @@
+package bigquery
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/option"
+)
+
+func TestConnector_SharedService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	aConnector := &connector{
+		cfg:     &Config{ProjectID: "p"},
+		options: []option.ClientOption{option.WithHTTPClient(server.Client()), option.WithEndpoint(server.URL)},
+	}
+	first, err := aConnector.Connect(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	second, err := aConnector.Connect(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Same(t, first.(*connection).service, second.(*connection).service, "connections share service")
+
+	aConnector.shared.stale.Store(true)
+	third, err := aConnector.Connect(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NotSame(t, first.(*connection).service, third.(*connection).service, "stale service is rebuilt")
+}
+
+func TestAuthObserver(t *testing.T) {
+	var testCases = []struct {
+		description string
+		status      int
+		expectStale bool
+	}{
+		{description: "success", status: http.StatusOK},
+		{description: "permission denied", status: http.StatusForbidden},
+		{description: "unauthorized", status: http.StatusUnauthorized, expectStale: true},
+	}
+	for _, testCase := range testCases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(testCase.status)
+		}))
+		shared := &sharedService{}
+		response, err := shared.observe(server.Client().Transport).Get(server.URL)
+		if assert.NoError(t, err, testCase.description) {
+			_ = response.Body.Close()
+		}
+		assert.Equal(t, testCase.expectStale, shared.stale.Load(), testCase.description)
+		server.Close()
+	}
+}
+
+func TestConfig_Transport(t *testing.T) {
+	cfg, err := ParseDSN("bigquery://p/us/ds?maxIdleConns=50&maxIdleConnsPerHost=10&idleConnTimeout=30s&http2=false")
+	if !assert.NoError(t, err) {
+		return
+	}
+	transport := cfg.transport()
+	assert.Equal(t, 50, transport.MaxIdleConns)
+	assert.Equal(t, 10, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 30*time.Second, transport.IdleConnTimeout)
+	assert.False(t, transport.ForceAttemptHTTP2)
+	assert.NotNil(t, transport.TLSNextProto)
+
+	transport = NewConfig().transport()
+	assert.Equal(t, defaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	assert.True(t, transport.ForceAttemptHTTP2)
+}
@@ -0,0 +1,124 @@
+package bigquery
+
+import (
+	"fmt"
+	"github.com/viant/bigquery/internal/ingestion"
+	"google.golang.org/api/bigquery/v2"
+	"strings"
+)
+
+const (
+	maxClusteringFields = 4
+)
+
+var (
+	writeDispositions = map[string]string{
+		"WRITE_TRUNCATE": "WRITE_TRUNCATE",
+		"TRUNCATE":       "WRITE_TRUNCATE",
+		"WRITE_APPEND":   "WRITE_APPEND",
+		"APPEND":         "WRITE_APPEND",
+		"WRITE_EMPTY":    "WRITE_EMPTY",
+		"EMPTY":          "WRITE_EMPTY",
+	}
+	createDispositions = map[string]string{
+		"CREATE_IF_NEEDED": "CREATE_IF_NEEDED",
+		"IF_NEEDED":        "CREATE_IF_NEEDED",
+		"CREATE_NEVER":     "CREATE_NEVER",
+		"NEVER":            "CREATE_NEVER",
+	}
+	partitioningTypes = map[string]bool{"HOUR": true, "DAY": true, "MONTH": true, "YEAR": true}
+)
+
+// initDestination initialises query result destination options, options are validated only with Destination, Partition or ClusterBy keys,
+// raw JobConfigurationQuery options are passed to BigQuery as is
+func (h *queryHint) initDestination(projectID, datasetID string) error {
+	config := &h.JobConfigurationQuery
+	if h.Destination != "" {
+		if config.DestinationTable != nil {
+			return fmt.Errorf("invalid hint: Destination and DestinationTable are mutually exclusive")
+		}
+		var err error
+		if config.DestinationTable, err = ingestion.ParseDestination(h.Destination, projectID, datasetID); err != nil {
+			return fmt.Errorf("invalid hint: %w", err)
+		}
+	}
+	if h.Partition != "" {
+		if config.TimePartitioning != nil {
+			return fmt.Errorf("invalid hint: Partition and TimePartitioning are mutually exclusive")
+		}
+		config.TimePartitioning = &bigquery.TimePartitioning{Type: h.Partition}
+		if index := strings.Index(h.Partition, ":"); index != -1 {
+			config.TimePartitioning.Type = h.Partition[:index]
+			config.TimePartitioning.Field = h.Partition[index+1:]
+		}
+	}
+	if len(h.ClusterBy) > 0 {
+		if config.Clustering != nil {
+			return fmt.Errorf("invalid hint: ClusterBy and Clustering are mutually exclusive")
+		}
+		config.Clustering = &bigquery.Clustering{Fields: h.ClusterBy}
+	}
+	normalizeDestination(config)
+	if h.Destination == "" && h.Partition == "" && len(h.ClusterBy) == 0 {
+		return nil
+	}
+	return validateDestination(config)
+}
+
+// normalizeDestination replaces disposition aliases with BigQuery names and upper cases time partitioning type
+func normalizeDestination(config *bigquery.JobConfigurationQuery) {
+	if disposition, ok := writeDispositions[strings.ToUpper(config.WriteDisposition)]; ok {
+		config.WriteDisposition = disposition
+	}
+	if disposition, ok := createDispositions[strings.ToUpper(config.CreateDisposition)]; ok {
+		config.CreateDisposition = disposition
+	}
+	if config.TimePartitioning != nil {
+		config.TimePartitioning.Type = strings.ToUpper(config.TimePartitioning.Type)
+	}
+}
+
+// validateDestination validates normalized query result destination options
+func validateDestination(config *bigquery.JobConfigurationQuery) error {
+	hasDestination := config.DestinationTable != nil
+	if config.WriteDisposition != "" && writeDispositions[config.WriteDisposition] == "" {
+		return fmt.Errorf("invalid hint: unsupported WriteDisposition: %v, supported: [WRITE_TRUNCATE|WRITE_APPEND|WRITE_EMPTY]", config.WriteDisposition)
+	}
+	if config.CreateDisposition != "" && createDispositions[config.CreateDisposition] == "" {
+		return fmt.Errorf("invalid hint: unsupported CreateDisposition: %v, supported: [CREATE_IF_NEEDED|CREATE_NEVER]", config.CreateDisposition)
+	}
+	if partitioning := config.TimePartitioning; partitioning != nil {
+		if !partitioningTypes[partitioning.Type] {
+			return fmt.Errorf("invalid hint: unsupported partitioning type: %v, supported: [HOUR|DAY|MONTH|YEAR]", partitioning.Type)
+		}
+		if config.RangePartitioning != nil {
+			return fmt.Errorf("invalid hint: TimePartitioning and RangePartitioning are mutually exclusive")
+		}
+	}
+	if clustering := config.Clustering; clustering != nil {
+		if count := len(clustering.Fields); count == 0 || count > maxClusteringFields {
+			return fmt.Errorf("invalid hint: clustering requires 1 to %v fields, but had: %v", maxClusteringFields, count)
+		}
+	}
+	if !hasDestination {
+		switch {
+		case config.WriteDisposition != "":
+			return fmt.Errorf("invalid hint: WriteDisposition requires destination table")
+		case config.CreateDisposition != "":
+			return fmt.Errorf("invalid hint: CreateDisposition requires destination table")
+		case config.TimePartitioning != nil, config.RangePartitioning != nil:
+			return fmt.Errorf("invalid hint: partitioning requires destination table")
+		case config.Clustering != nil:
+			return fmt.Errorf("invalid hint: clustering requires destination table")
+		}
+	}
+	if config.AllowLargeResults {
+		if config.UseLegacySql == nil || !*config.UseLegacySql {
+			return fmt.Errorf("invalid hint: AllowLargeResults is only supported with legacy SQL")
+		}
+		if !hasDestination {
+			return fmt.Errorf("invalid hint: AllowLargeResults requires destination table")
+		}
+	}
+	return nil
+}
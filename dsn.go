@@ -3,31 +3,48 @@ package bigquery
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"github.com/viant/bigquery/internal/label"
 	"github.com/viant/scy"
 	"google.golang.org/api/option"
+	"log/slog"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
 const (
-	bigqueryScheme     = "bigquery"
-	credentialsJSON    = "credJSON"
-	credentialsURL     = "credURL"
-	credentialsKey     = "credKey"
-	credID             = "credID"
-	oAuth2ConfigURLKey = "oauth2ClientURL"
-	oAuth2TokenURLKey  = "oauth2TokenURL"
-	endpoint           = "endpoint"
-	userAgent          = "ua"
-	apiKey             = "apiKey"
-	quotaProject       = "quotaProject"
-	scopes             = "scopes"
-	app                = "app"
-	defaultApp         = "go-sql-bq"
-	priority           = "priority"
-	reservation        = "reservation"
+	bigqueryScheme      = "bigquery"
+	credentialsJSON     = "credJSON"
+	credentialsURL      = "credURL"
+	credentialsKey      = "credKey"
+	credID              = "credID"
+	oAuth2ConfigURLKey  = "oauth2ClientURL"
+	oAuth2TokenURLKey   = "oauth2TokenURL"
+	oAuth2TokenKey      = "oauth2TokenKey"
+	endpoint            = "endpoint"
+	userAgent           = "ua"
+	apiKey              = "apiKey"
+	quotaProject        = "quotaProject"
+	scopes              = "scopes"
+	app                 = "app"
+	defaultApp          = "go-sql-bq"
+	priority            = "priority"
+	reservation         = "reservation"
+	labels              = "labels"
+	redact              = "redact"
+	maxIdleConns        = "maxIdleConns"
+	maxIdleConnsPerHost = "maxIdleConnsPerHost"
+	idleConnTimeout     = "idleConnTimeout"
+	http2               = "http2"
+	impersonateKey      = "impersonate"
+	delegates           = "delegates"
+	externalAccount     = "externalAccount"
+	externalAccountType = "external_account"
+	maxIdentities       = "maxIdentities"
 
 	// Priority values
 	PriorityInteractive = "INTERACTIVE"
@@ -54,21 +71,47 @@ type Config struct {
 	App             string
 	OAuth2ConfigURL string
 	OAuth2TokenURL  string
-	Priority    string // Job priority: "INTERACTIVE" (default) or "BATCH"
-	Reservation string // Reservation for query jobs: "projects/{project}/locations/{location}/reservations/{reservation}"
+	OAuth2TokenKey  string            // scy key encrypting refreshed tokens stored to OAuth2TokenURL, blowfish://default by default, empty DSN value stores plain JSON
+	Priority        string            // Job priority: "INTERACTIVE" (default) or "BATCH"
+	Reservation     string            // Reservation for query jobs: "projects/{project}/locations/{location}/reservations/{reservation}"
+	Labels          map[string]string // Job labels: "key1:value1,key2:value2"
+	//Impersonate service account impersonated with DSN, OAuth2 or application default credentials
+	Impersonate string
+	//Delegates impersonation delegation chain service accounts
+	Delegates []string
+	//ExternalAccountURL scy URL of workload identity federation (external_account) credentials configuration
+	ExternalAccountURL string
+	Logger             *slog.Logger // Driver logger, the driver is silent if nil
+	LogRedaction       LogRedaction // Logged SQL redaction: "literals,params"
+	// Shared HTTP transport settings
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	DisableHTTP2        bool
+	MaxIdentities       int // Max cached per context credentials clients, see WithTokenSource
 	url.Values
 }
 
+// oauth2TokenKey returns OAuth2 token key, false if neither DSN nor config sets it, thus the default key applies
+func (c *Config) oauth2TokenKey() (string, bool) {
+	if _, ok := c.Values[oAuth2TokenKey]; ok {
+		return c.OAuth2TokenKey, true
+	}
+	return c.OAuth2TokenKey, c.OAuth2TokenKey != ""
+}
+
 // hasCred returns ture if config has credential configured
 func (c *Config) hasCred() bool {
-	return c.CredID != "" || len(c.CredentialJSON) > 0 || c.CredentialsURL != "" || c.CredentialsFile != ""
+	return c.CredID != "" || len(c.CredentialJSON) > 0 || c.CredentialsURL != "" || c.CredentialsFile != "" || c.ExternalAccountURL != ""
 }
 
 func (c *Config) options() []option.ClientOption {
+	return append(c.serviceOptions(), c.credentialOptions()...)
+}
+
+// serviceOptions returns non credential client options
+func (c *Config) serviceOptions() []option.ClientOption {
 	var result = make([]option.ClientOption, 0)
-	if c.CredentialsFile != "" {
-		result = append(result, option.WithCredentialsFile(c.CredentialsFile))
-	}
 	if c.Endpoint != "" {
 		result = append(result, option.WithEndpoint(c.Endpoint))
 	}
@@ -81,16 +124,24 @@ func (c *Config) options() []option.ClientOption {
 	if c.QuotaProject != "" {
 		result = append(result, option.WithQuotaProject(c.QuotaProject))
 	}
-	if len(c.CredentialJSON) > 0 {
-		result = append(result, option.WithCredentialsJSON(c.CredentialJSON))
-	}
-
 	if len(c.Scopes) > 0 {
 		result = append(result, option.WithScopes(c.Scopes...))
 	}
 	return result
 }
 
+// credentialOptions returns credentials file or JSON client options
+func (c *Config) credentialOptions() []option.ClientOption {
+	var result []option.ClientOption
+	if c.CredentialsFile != "" {
+		result = append(result, option.WithCredentialsFile(c.CredentialsFile))
+	}
+	if len(c.CredentialJSON) > 0 {
+		result = append(result, option.WithCredentialsJSON(c.CredentialJSON))
+	}
+	return result
+}
+
 // NewConfig creates a new Config and sets default values.
 func NewConfig() *Config {
 	return &Config{}
@@ -134,6 +185,9 @@ func ParseDSN(dsn string) (*Config, error) {
 		if _, ok := cfg.Values[oAuth2TokenURLKey]; ok {
 			cfg.OAuth2TokenURL = cfg.Values.Get(oAuth2TokenURLKey)
 		}
+		if _, ok := cfg.Values[oAuth2TokenKey]; ok {
+			cfg.OAuth2TokenKey = cfg.Values.Get(oAuth2TokenKey)
+		}
 		if _, ok := cfg.Values[app]; ok {
 			cfg.App = cfg.Values.Get(app)
 		}
@@ -161,6 +215,58 @@ func ParseDSN(dsn string) (*Config, error) {
 		if _, ok := cfg.Values[reservation]; ok {
 			cfg.Reservation = cfg.Values.Get(reservation)
 		}
+		if _, ok := cfg.Values[labels]; ok {
+			if cfg.Labels, err = parseLabels(cfg.Values.Get(labels)); err != nil {
+				return nil, err
+			}
+		}
+		if _, ok := cfg.Values[impersonateKey]; ok {
+			cfg.Impersonate = cfg.Values.Get(impersonateKey)
+		}
+		if _, ok := cfg.Values[delegates]; ok {
+			for _, value := range cfg.Values[delegates] {
+				for _, delegate := range strings.Split(value, ",") {
+					if delegate = strings.TrimSpace(delegate); delegate != "" {
+						cfg.Delegates = append(cfg.Delegates, delegate)
+					}
+				}
+			}
+		}
+		if _, ok := cfg.Values[externalAccount]; ok {
+			cfg.ExternalAccountURL = cfg.Values.Get(externalAccount)
+		}
+		if _, ok := cfg.Values[maxIdleConns]; ok {
+			if cfg.MaxIdleConns, err = strconv.Atoi(cfg.Values.Get(maxIdleConns)); err != nil {
+				return nil, fmt.Errorf("invalid dsn %v: %w", maxIdleConns, err)
+			}
+		}
+		if _, ok := cfg.Values[maxIdleConnsPerHost]; ok {
+			if cfg.MaxIdleConnsPerHost, err = strconv.Atoi(cfg.Values.Get(maxIdleConnsPerHost)); err != nil {
+				return nil, fmt.Errorf("invalid dsn %v: %w", maxIdleConnsPerHost, err)
+			}
+		}
+		if _, ok := cfg.Values[idleConnTimeout]; ok {
+			if cfg.IdleConnTimeout, err = time.ParseDuration(cfg.Values.Get(idleConnTimeout)); err != nil {
+				return nil, fmt.Errorf("invalid dsn %v: %w", idleConnTimeout, err)
+			}
+		}
+		if _, ok := cfg.Values[maxIdentities]; ok {
+			if cfg.MaxIdentities, err = strconv.Atoi(cfg.Values.Get(maxIdentities)); err != nil {
+				return nil, fmt.Errorf("invalid dsn %v: %w", maxIdentities, err)
+			}
+		}
+		if _, ok := cfg.Values[http2]; ok {
+			enabled, err := strconv.ParseBool(cfg.Values.Get(http2))
+			if err != nil {
+				return nil, fmt.Errorf("invalid dsn %v: %w", http2, err)
+			}
+			cfg.DisableHTTP2 = !enabled
+		}
+		if _, ok := cfg.Values[redact]; ok {
+			if cfg.LogRedaction, err = parseRedaction(cfg.Values.Get(redact)); err != nil {
+				return nil, fmt.Errorf("invalid dsn: %w", err)
+			}
+		}
 	}
 
 	if cfg.CredentialsKey != "" {
@@ -169,6 +275,9 @@ func ParseDSN(dsn string) (*Config, error) {
 		}
 	}
 
+	if cfg.ExternalAccountURL != "" && (cfg.CredID != "" || cfg.CredentialsURL != "" || len(cfg.CredentialJSON) > 0) {
+		return nil, fmt.Errorf("invalid dsn: %v can not be combined with %v, %v or %v", externalAccount, credID, credentialsURL, credentialsJSON)
+	}
 	if err = cfg.initialiseSecrets(); err != nil {
 		return nil, err
 	}
@@ -182,6 +291,9 @@ func ParseDSN(dsn string) (*Config, error) {
 	if cfg.Priority == "" {
 		cfg.Priority = PriorityInteractive
 	}
+	if err = label.Validate(cfg.jobLabels()); err != nil {
+		return nil, fmt.Errorf("invalid dsn: %w", err)
+	}
 	return cfg, nil
 }
 
@@ -201,6 +313,27 @@ func (c *Config) initialiseSecrets() error {
 			c.CredentialJSON = raw
 		}
 	}
+	return c.loadSecrets()
+}
+
+// reloadSecrets reloads CredID and CredentialsURL credentials bypassing the registry cache, i.e. after credentials rotation
+func (c *Config) reloadSecrets() error {
+	if c.CredID != "" {
+		if resource := scy.Resources().Lookup(c.CredID); resource != nil {
+			credentials.evict(resource.URL)
+		}
+	}
+	if c.CredentialsURL != "" {
+		credentials.evict(c.CredentialsURL)
+	}
+	if c.ExternalAccountURL != "" {
+		credentials.evict(c.ExternalAccountURL)
+	}
+	return c.loadSecrets()
+}
+
+// loadSecrets loads CredID and CredentialsURL credentials
+func (c *Config) loadSecrets() error {
 	if c.CredID != "" {
 		resource := scy.Resources().Lookup(c.CredID)
 		if resource == nil {
@@ -221,6 +354,18 @@ func (c *Config) initialiseSecrets() error {
 		c.CredentialJSON = []byte(credentialJSON)
 
 	}
+	if c.ExternalAccountURL != "" {
+		//named resource keeps raw payload, generic credentials decoding would drop external account fields
+		credentialJSON, err := credentials.lookup(&scy.Resource{Name: externalAccount, URL: c.ExternalAccountURL, Key: c.CredentialsKey})
+		if err != nil {
+			return err
+		}
+		account := struct{ Type string }{}
+		if err = json.Unmarshal([]byte(credentialJSON), &account); err != nil || account.Type != externalAccountType {
+			return fmt.Errorf("invalid %v credentials: %v, expected %v type", externalAccount, c.ExternalAccountURL, externalAccountType)
+		}
+		c.CredentialJSON = []byte(credentialJSON)
+	}
 	return nil
 }
 
@@ -248,4 +393,10 @@ func (r *credentialsRegistry) lookup(resource *scy.Resource) (string, error) {
 	return secrets.String(), nil
 }
 
+func (r *credentialsRegistry) evict(URL string) {
+	r.RWMutex.Lock()
+	delete(r.registry, URL)
+	r.RWMutex.Unlock()
+}
+
 var credentials = credentialsRegistry{registry: map[string]string{}, service: scy.New()}
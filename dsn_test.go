@@ -70,6 +70,56 @@ func TestParseDSN(t *testing.T) {
 				Priority:  PriorityInteractive,
 			},
 		},
+		{
+			description: "DSN with labels",
+			dsn:         "bigquery://myproject/us/mydataset?labels=team:ads,service:api",
+			expect: Config{
+				ProjectID: "myproject",
+				DatasetID: "mydataset",
+				Location:  "us",
+				App:       defaultApp,
+				Priority:  PriorityInteractive,
+				Labels:    map[string]string{"team": "ads", "service": "api"},
+			},
+		},
+		{
+			description: "DSN with malformed labels",
+			dsn:         "bigquery://myproject/us/mydataset?labels=team",
+			expectError: true,
+		},
+		{
+			description: "DSN with app not being a valid label value",
+			dsn:         "bigquery://myproject/us/mydataset?app=MyService/1.2",
+			expect: Config{
+				ProjectID: "myproject",
+				DatasetID: "mydataset",
+				Location:  "us",
+				App:       "MyService/1.2",
+				Priority:  PriorityInteractive,
+			},
+		},
+		{
+			description: "DSN with invalid label value",
+			dsn:         "bigquery://myproject/us/mydataset?labels=team:Ads",
+			expectError: true,
+		},
+		{
+			description: "DSN with log redaction",
+			dsn:         "bigquery://myproject/us/mydataset?redact=literals,params",
+			expect: Config{
+				ProjectID:    "myproject",
+				DatasetID:    "mydataset",
+				Location:     "us",
+				App:          defaultApp,
+				Priority:     PriorityInteractive,
+				LogRedaction: RedactLiterals | RedactParameters,
+			},
+		},
+		{
+			description: "DSN with invalid log redaction",
+			dsn:         "bigquery://myproject/us/mydataset?redact=names",
+			expectError: true,
+		},
 		{
 			description: "invalid scheme",
 			dsn:         "postgres://myproject/mydataset",
@@ -93,6 +143,30 @@ func TestParseDSN(t *testing.T) {
 			assert.Equal(t, tc.expect.Priority, cfg.Priority)
 			assert.Equal(t, tc.expect.Reservation, cfg.Reservation)
 			assert.Equal(t, tc.expect.App, cfg.App)
+			assert.Equal(t, tc.expect.Labels, cfg.Labels)
+			assert.Equal(t, tc.expect.LogRedaction, cfg.LogRedaction)
 		})
 	}
 }
+
+func TestConfig_OAuth2TokenKey(t *testing.T) {
+	var testCases = []struct {
+		description string
+		dsn         string
+		expectKey   string
+		expectOk    bool
+	}{
+		{description: "default key", dsn: "bigquery://p/d"},
+		{description: "custom key", dsn: "bigquery://p/d?oauth2TokenKey=blowfish://custom", expectKey: "blowfish://custom", expectOk: true},
+		{description: "empty key selects plain JSON", dsn: "bigquery://p/d?oauth2TokenKey=", expectOk: true},
+	}
+	for _, testCase := range testCases {
+		cfg, err := ParseDSN(testCase.dsn)
+		if !assert.NoError(t, err, testCase.description) {
+			continue
+		}
+		key, ok := cfg.oauth2TokenKey()
+		assert.Equal(t, testCase.expectKey, key, testCase.description)
+		assert.Equal(t, testCase.expectOk, ok, testCase.description)
+	}
+}
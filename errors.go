@@ -0,0 +1,46 @@
+package bigquery
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+	"net/http"
+)
+
+// Connection validation errors, classified errors wrap both the sentinel and the original error
+var (
+	// ErrUnauthenticated indicates invalid, expired or revoked credentials
+	ErrUnauthenticated = errors.New("bigquery: unauthenticated")
+	// ErrPermissionDenied indicates credentials lacking permission to the project or dataset
+	ErrPermissionDenied = errors.New("bigquery: permission denied")
+	// ErrNotFound indicates a missing project or dataset
+	ErrNotFound = errors.New("bigquery: not found")
+)
+
+// classifyError wraps err with authentication, permission or not found sentinel error, other errors are returned as is
+func classifyError(err error) error {
+	var retrieveError *oauth2.RetrieveError
+	if errors.As(err, &retrieveError) {
+		return fmt.Errorf("%w, %w", ErrUnauthenticated, err)
+	}
+	var apiError *googleapi.Error
+	if !errors.As(err, &apiError) {
+		return err
+	}
+	switch apiError.Code {
+	case http.StatusUnauthorized:
+		return fmt.Errorf("%w, %w", ErrUnauthenticated, err)
+	case http.StatusForbidden:
+		if len(apiError.Errors) > 0 {
+			switch apiError.Errors[0].Reason {
+			case "rateLimitExceeded", "quotaExceeded":
+				return err
+			}
+		}
+		return fmt.Errorf("%w, %w", ErrPermissionDenied, err)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w, %w", ErrNotFound, err)
+	}
+	return err
+}
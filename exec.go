@@ -3,13 +3,15 @@ package bigquery
 import (
 	"context"
 	"database/sql/driver"
-	"github.com/viant/bigquery/internal/ingestion"
+	"github.com/viant/bigquery/internal/logging"
+	"github.com/viant/bigquery/internal/trace"
 )
 
+// ingestionStatement represents LOAD/STREAM/EXPORT TABLE statement, ingestion service is resolved at execution with context credentials
 type ingestionStatement struct {
-	service *ingestion.Service
-	ctx     context.Context
-	SQL     string
+	conn *connection
+	ctx  context.Context
+	SQL  string
 }
 
 // Close dummy function because interface requires it
@@ -24,7 +26,19 @@ func (s *ingestionStatement) NumInput() int {
 
 // Exec executes a query that doesn't return rows, such as an LOAD
 func (s *ingestionStatement) Exec(args []driver.Value) (driver.Result, error) {
-	affected, err := s.service.Ingest(s.ctx, s.SQL)
+	return s.ExecContext(s.ctx, nil)
+}
+
+// ExecContext executes a query that doesn't return rows, such as an LOAD
+func (s *ingestionStatement) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	ctx, span := trace.Start(logging.WithDefault(ctx, s.conn.logger), "bigquery.Exec", trace.JobKindKey.String("ingestion"))
+	var affected int64
+	service, err := s.conn.ingestionService(ctx)
+	if err == nil {
+		affected, err = service.Ingest(ctx, s.SQL)
+	}
+	span.SetAttributes(trace.AffectedRowsKey.Int64(affected))
+	trace.End(span, err)
 	res := result{}
 	res.totalRows = affected
 	return &res, err
@@ -0,0 +1,287 @@
+package export
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/bigquery/internal/schema"
+	"github.com/viant/bigquery/internal/wire"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	ratType   = reflect.TypeOf(big.Rat{})
+	bytesType = reflect.TypeOf([]byte{})
+)
+
+// encoder encodes non nil value as JSON
+type encoder func(buffer *bytes.Buffer, value reflect.Value) error
+
+// newEncoder returns JSON encoder for scan type, BigQuery data type drives temporal and numeric formatting
+func newEncoder(rType reflect.Type, dataType string) (encoder, error) {
+	switch rType {
+	case timeType:
+		format := wire.TimeFormatter(dataType)
+		return func(buffer *bytes.Buffer, value reflect.Value) error {
+			return encodeString(buffer, format(value.Interface().(time.Time)))
+		}, nil
+	case ratType:
+		scale := wire.Scale(dataType)
+		return func(buffer *bytes.Buffer, value reflect.Value) error {
+			rat := value.Interface().(big.Rat)
+			return encodeString(buffer, rat.FloatString(scale))
+		}, nil
+	case bytesType:
+		return func(buffer *bytes.Buffer, value reflect.Value) error {
+			return encodeString(buffer, base64.StdEncoding.EncodeToString(value.Bytes()))
+		}, nil
+	}
+	switch rType.Kind() {
+	case reflect.Ptr:
+		elem, err := newEncoder(rType.Elem(), dataType)
+		if err != nil {
+			return nil, err
+		}
+		return func(buffer *bytes.Buffer, value reflect.Value) error {
+			if value.IsNil() {
+				buffer.WriteString("null")
+				return nil
+			}
+			return elem(buffer, value.Elem())
+		}, nil
+	case reflect.String:
+		return func(buffer *bytes.Buffer, value reflect.Value) error {
+			return encodeString(buffer, value.String())
+		}, nil
+	case reflect.Bool:
+		return func(buffer *bytes.Buffer, value reflect.Value) error {
+			buffer.WriteString(strconv.FormatBool(value.Bool()))
+			return nil
+		}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(buffer *bytes.Buffer, value reflect.Value) error {
+			buffer.WriteString(strconv.FormatInt(value.Int(), 10))
+			return nil
+		}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return func(buffer *bytes.Buffer, value reflect.Value) error {
+			buffer.WriteString(strconv.FormatUint(value.Uint(), 10))
+			return nil
+		}, nil
+	case reflect.Float32, reflect.Float64:
+		return func(buffer *bytes.Buffer, value reflect.Value) error {
+			wire.EncodeFloat(buffer, value.Float(), value.Type().Bits())
+			return nil
+		}, nil
+	case reflect.Slice:
+		elem, err := newEncoder(rType.Elem(), dataType)
+		if err != nil {
+			return nil, err
+		}
+		return func(buffer *bytes.Buffer, value reflect.Value) error {
+			buffer.WriteByte('[')
+			for i := 0; i < value.Len(); i++ {
+				if i > 0 {
+					buffer.WriteByte(',')
+				}
+				if err := elem(buffer, value.Index(i)); err != nil {
+					return err
+				}
+			}
+			buffer.WriteByte(']')
+			return nil
+		}, nil
+	case reflect.Struct:
+		return newStructEncoder(rType, dataType)
+	case reflect.Interface:
+		return func(buffer *bytes.Buffer, value reflect.Value) error {
+			if value.IsNil() {
+				buffer.WriteString("null")
+				return nil
+			}
+			elem, err := newEncoder(value.Elem().Type(), dataType)
+			if err != nil {
+				return err
+			}
+			return elem(buffer, value.Elem())
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported type: %v", rType)
+}
+
+type fieldEncoder struct {
+	index  int
+	key    []byte
+	encode encoder
+}
+
+// newStructEncoder returns RECORD encoder, keys are taken from json tags, field data types from STRUCT<name T, ...> data type
+func newStructEncoder(rType reflect.Type, dataType string) (encoder, error) {
+	var fields []*fieldEncoder
+	types := fieldTypes(dataType)
+	for i := 0; i < rType.NumField(); i++ {
+		field := rType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := fieldName(field)
+		encode, err := newEncoder(field.Type, types[strings.ToLower(name)])
+		if err != nil {
+			return nil, fmt.Errorf("failed to create encoder for field: %v, %w", field.Name, err)
+		}
+		key, _ := json.Marshal(name)
+		fields = append(fields, &fieldEncoder{index: i, key: key, encode: encode})
+	}
+	return func(buffer *bytes.Buffer, value reflect.Value) error {
+		buffer.WriteByte('{')
+		for i, field := range fields {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			buffer.Write(field.key)
+			buffer.WriteByte(':')
+			if err := field.encode(buffer, value.Field(field.index)); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte('}')
+		return nil
+	}, nil
+}
+
+// textEncoder returns text representation of non nil value
+type textEncoder func(value reflect.Value) (string, error)
+
+// newTextEncoder returns text encoder, RECORD and REPEATED values are JSON encoded
+func newTextEncoder(rType reflect.Type, dataType string) (textEncoder, error) {
+	switch rType {
+	case timeType:
+		format := wire.TimeFormatter(dataType)
+		return func(value reflect.Value) (string, error) {
+			return format(value.Interface().(time.Time)), nil
+		}, nil
+	case ratType:
+		scale := wire.Scale(dataType)
+		return func(value reflect.Value) (string, error) {
+			rat := value.Interface().(big.Rat)
+			return rat.FloatString(scale), nil
+		}, nil
+	case bytesType:
+		return func(value reflect.Value) (string, error) {
+			return base64.StdEncoding.EncodeToString(value.Bytes()), nil
+		}, nil
+	}
+	switch rType.Kind() {
+	case reflect.Ptr:
+		elem, err := newTextEncoder(rType.Elem(), dataType)
+		if err != nil {
+			return nil, err
+		}
+		return func(value reflect.Value) (string, error) {
+			if value.IsNil() {
+				return "", nil
+			}
+			return elem(value.Elem())
+		}, nil
+	case reflect.String:
+		return func(value reflect.Value) (string, error) {
+			return value.String(), nil
+		}, nil
+	case reflect.Bool:
+		return func(value reflect.Value) (string, error) {
+			return strconv.FormatBool(value.Bool()), nil
+		}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(value reflect.Value) (string, error) {
+			return strconv.FormatInt(value.Int(), 10), nil
+		}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return func(value reflect.Value) (string, error) {
+			return strconv.FormatUint(value.Uint(), 10), nil
+		}, nil
+	case reflect.Float32, reflect.Float64:
+		return func(value reflect.Value) (string, error) {
+			return wire.FormatFloat(value.Float(), value.Type().Bits()), nil
+		}, nil
+	case reflect.Interface:
+		return func(value reflect.Value) (string, error) {
+			if value.IsNil() {
+				return "", nil
+			}
+			elem, err := newTextEncoder(value.Elem().Type(), dataType)
+			if err != nil {
+				return "", err
+			}
+			return elem(value.Elem())
+		}, nil
+	}
+	encode, err := newEncoder(rType, dataType)
+	if err != nil {
+		return nil, err
+	}
+	return func(value reflect.Value) (string, error) {
+		buffer := new(bytes.Buffer)
+		if err := encode(buffer, value); err != nil {
+			return "", err
+		}
+		return buffer.String(), nil
+	}, nil
+}
+
+// valueOf returns reflect value of row value, dereferencing pointers, returns false for nil values
+func valueOf(value interface{}) (reflect.Value, bool) {
+	result := reflect.ValueOf(value)
+	for result.Kind() == reflect.Ptr {
+		if result.IsNil() {
+			return result, false
+		}
+		result = result.Elem()
+	}
+	return result, result.IsValid()
+}
+
+// baseType returns non pointer type
+func baseType(rType reflect.Type) reflect.Type {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	return rType
+}
+
+// fieldName returns RECORD field name defined by json tag or Go field name
+func fieldName(field reflect.StructField) string {
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		if name := strings.Split(tag, ",")[0]; name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// fieldTypes returns RECORD field data types by lower case field name, data type is STRUCT<name T, ...> or ARRAY<STRUCT<name T, ...>>
+func fieldTypes(dataType string) map[string]string {
+	var result = map[string]string{}
+	record, err := schema.ParseTypeName(dataType)
+	if err != nil {
+		return result
+	}
+	for _, field := range record.Fields {
+		result[strings.ToLower(field.Name)] = schema.TypeName(field)
+	}
+	return result
+}
+
+func encodeString(buffer *bytes.Buffer, value string) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	buffer.Write(data)
+	return nil
+}
@@ -0,0 +1,165 @@
+// Package export streams query results as CSV, newline delimited JSON or Parquet.
+//
+// Values are written with driver scan types, RECORD and REPEATED columns are encoded
+// as nested objects, arrays, groups and lists rather than flattened text.
+package export
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Format represents export format
+type Format string
+
+const (
+	// CSV exports rows as comma separated values with header, RECORD and REPEATED values are JSON encoded
+	CSV = Format("csv")
+	// NDJSON exports rows as newline delimited JSON
+	NDJSON = Format("ndjson")
+	// Parquet exports rows as Parquet file
+	Parquet = Format("parquet")
+)
+
+// WriteRows writes sql rows to writer in the format, returns number of written rows
+func WriteRows(writer io.Writer, rows *sql.Rows, format Format) (int, error) {
+	return Write(writer, FromRows(rows), format)
+}
+
+// Write writes source rows to writer in the format, returns number of written rows
+func Write(writer io.Writer, source Source, format Format) (int, error) {
+	columns, err := source.Columns()
+	if err != nil {
+		return 0, err
+	}
+	switch format {
+	case CSV:
+		return writeCSV(writer, source, columns)
+	case NDJSON:
+		return writeNDJSON(writer, source, columns)
+	case Parquet:
+		return writeParquet(writer, source, columns)
+	}
+	return 0, fmt.Errorf("unsupported format: %v, supported: [%v|%v|%v]", format, CSV, NDJSON, Parquet)
+}
+
+func writeCSV(writer io.Writer, source Source, columns []*Column) (int, error) {
+	var encoders = make([]*columnEncoder[textEncoder], len(columns))
+	var header = make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Name
+		encoders[i] = newColumnEncoder(column, newTextEncoder)
+		if _, err := encoders[i].encoder(baseType(column.ScanType)); err != nil {
+			return 0, fmt.Errorf("failed to create encoder for column: %v, %w", column.Name, err)
+		}
+	}
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write(header); err != nil {
+		return 0, err
+	}
+	count, err := readRows(source, len(columns), func(values []interface{}) error {
+		record := make([]string, len(values))
+		for i, value := range values {
+			aValue, ok := valueOf(value)
+			if !ok {
+				continue
+			}
+			encode, err := encoders[i].encoder(aValue.Type())
+			if err != nil {
+				return err
+			}
+			if record[i], err = encode(aValue); err != nil {
+				return fmt.Errorf("failed to encode column: %v, %w", columns[i].Name, err)
+			}
+		}
+		return csvWriter.Write(record)
+	})
+	csvWriter.Flush()
+	if err == nil {
+		err = csvWriter.Error()
+	}
+	return count, err
+}
+
+func writeNDJSON(writer io.Writer, source Source, columns []*Column) (int, error) {
+	var encoders = make([]*columnEncoder[encoder], len(columns))
+	var keys = make([][]byte, len(columns))
+	for i, column := range columns {
+		key, _ := json.Marshal(column.Name)
+		keys[i] = append(key, ':')
+		encoders[i] = newColumnEncoder(column, newEncoder)
+		if _, err := encoders[i].encoder(baseType(column.ScanType)); err != nil {
+			return 0, fmt.Errorf("failed to create encoder for column: %v, %w", column.Name, err)
+		}
+	}
+	buffer := new(bytes.Buffer)
+	return readRows(source, len(columns), func(values []interface{}) error {
+		buffer.Reset()
+		buffer.WriteByte('{')
+		for i, value := range values {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			buffer.Write(keys[i])
+			aValue, ok := valueOf(value)
+			if !ok {
+				buffer.WriteString("null")
+				continue
+			}
+			encode, err := encoders[i].encoder(aValue.Type())
+			if err != nil {
+				return err
+			}
+			if err = encode(buffer, aValue); err != nil {
+				return fmt.Errorf("failed to encode column: %v, %w", columns[i].Name, err)
+			}
+		}
+		buffer.WriteString("}\n")
+		_, err := writer.Write(buffer.Bytes())
+		return err
+	})
+}
+
+// readRows reads source rows calling fn for each row, returns number of processed rows
+func readRows(source Source, columns int, fn func(values []interface{}) error) (int, error) {
+	var values = make([]interface{}, columns)
+	count := 0
+	for {
+		ok, err := source.Next(values)
+		if err != nil || !ok {
+			return count, err
+		}
+		if err = fn(values); err != nil {
+			return count, err
+		}
+		count++
+	}
+}
+
+// columnEncoder caches column encoders by value type, driver values may use non pointer or dynamic types
+type columnEncoder[T any] struct {
+	column   *Column
+	newFn    func(rType reflect.Type, dataType string) (T, error)
+	encoders map[reflect.Type]T
+}
+
+func (e *columnEncoder[T]) encoder(rType reflect.Type) (T, error) {
+	if result, ok := e.encoders[rType]; ok {
+		return result, nil
+	}
+	result, err := e.newFn(rType, e.column.Type)
+	if err != nil {
+		return result, err
+	}
+	e.encoders[rType] = result
+	return result, nil
+}
+
+func newColumnEncoder[T any](column *Column, newFn func(rType reflect.Type, dataType string) (T, error)) *columnEncoder[T] {
+	return &columnEncoder[T]{column: column, newFn: newFn, encoders: map[reflect.Type]T{}}
+}
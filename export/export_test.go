@@ -0,0 +1,226 @@
+package export
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"io"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/bigquery/internal/schema"
+	"google.golang.org/api/bigquery/v2"
+)
+
+// testRows represents driver rows returning values of schema scan types
+type testRows struct {
+	schema *bigquery.TableSchema
+	types  []reflect.Type
+	rows   [][]driver.Value
+	index  int
+}
+
+func (r *testRows) Columns() []string {
+	var result []string
+	for _, field := range r.schema.Fields {
+		result = append(result, field.Name)
+	}
+	return result
+}
+
+func (r *testRows) Close() error { return nil }
+
+func (r *testRows) Next(dest []driver.Value) error {
+	if r.index >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.index])
+	r.index++
+	return nil
+}
+
+func (r *testRows) ColumnTypeScanType(index int) reflect.Type { return r.types[index] }
+
+func (r *testRows) ColumnTypeDatabaseTypeName(index int) string {
+	return schema.TypeName(r.schema.Fields[index])
+}
+
+func (r *testRows) ColumnTypeNullable(index int) (bool, bool) {
+	return r.schema.Fields[index].Mode == "NULLABLE", true
+}
+
+var testSchema = &bigquery.TableSchema{Fields: []*bigquery.TableFieldSchema{
+	{Name: "id", Type: "INTEGER", Mode: "REQUIRED"},
+	{Name: "name", Type: "STRING", Mode: "NULLABLE"},
+	{Name: "amount", Type: "BIGNUMERIC", Mode: "REQUIRED"},
+	{Name: "day", Type: "DATE", Mode: "REQUIRED"},
+	{Name: "created", Type: "TIMESTAMP", Mode: "NULLABLE"},
+	{Name: "tags", Type: "STRING", Mode: "REPEATED"},
+	{Name: "address", Type: "RECORD", Mode: "NULLABLE", Fields: []*bigquery.TableFieldSchema{
+		{Name: "city", Type: "STRING", Mode: "NULLABLE"},
+		{Name: "zip", Type: "INTEGER", Mode: "REQUIRED"},
+		{Name: "since", Type: "DATE", Mode: "NULLABLE"},
+	}},
+}}
+
+func newTestRows(t *testing.T) *testRows {
+	types, err := schema.BuildSchemaTypes(testSchema)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	created := time.Date(2023, 4, 5, 6, 7, 8, 123000000, time.UTC)
+	city := "Austin"
+	address := reflect.New(types[6]).Elem()
+	address.Field(0).Set(reflect.ValueOf(&city))
+	address.Field(1).SetInt(78701)
+	address.Field(2).Set(reflect.ValueOf(&created))
+	return &testRows{schema: testSchema, types: types, rows: [][]driver.Value{
+		{1, "a,b", *big.NewRat(1, 4), created, created, []string{"x", "y"}, address.Interface()},
+		{2, nil, *big.NewRat(3, 1), created, nil, []string{}, reflect.New(types[6]).Elem().Interface()},
+	}}
+}
+
+func TestWrite(t *testing.T) {
+	var testCases = []struct {
+		description string
+		format      Format
+		expect      string
+		expectErr   bool
+	}{
+		{
+			description: "csv",
+			format:      CSV,
+			expect: "id,name,amount,day,created,tags,address\n" +
+				`1,"a,b",0.25000000000000000000000000000000000000,2023-04-05,2023-04-05T06:07:08.123Z,"[""x"",""y""]","{""city"":""Austin"",""zip"":78701,""since"":""2023-04-05""}"` + "\n" +
+				`2,,3.00000000000000000000000000000000000000,2023-04-05,,[],"{""city"":null,""zip"":0,""since"":null}"` + "\n",
+		},
+		{
+			description: "ndjson",
+			format:      NDJSON,
+			expect: `{"id":1,"name":"a,b","amount":"0.25000000000000000000000000000000000000","day":"2023-04-05","created":"2023-04-05T06:07:08.123Z","tags":["x","y"],"address":{"city":"Austin","zip":78701,"since":"2023-04-05"}}` + "\n" +
+				`{"id":2,"name":null,"amount":"3.00000000000000000000000000000000000000","day":"2023-04-05","created":null,"tags":[],"address":{"city":null,"zip":0,"since":null}}` + "\n",
+		},
+		{
+			description: "unsupported format",
+			format:      Format("xml"),
+			expectErr:   true,
+		},
+	}
+	for _, testCase := range testCases {
+		buffer := new(bytes.Buffer)
+		count, err := Write(buffer, FromDriverRows(newTestRows(t)), testCase.format)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, 2, count, testCase.description)
+		assert.EqualValues(t, testCase.expect, buffer.String(), testCase.description)
+	}
+}
+
+func TestWrite_Parquet(t *testing.T) {
+	type address struct {
+		City  *string `parquet:"city"`
+		Zip   int     `parquet:"zip"`
+		Since int32   `parquet:"since,date,optional"`
+	}
+	type record struct {
+		ID      int        `parquet:"id"`
+		Name    *string    `parquet:"name"`
+		Amount  [32]byte   `parquet:"amount,decimal(38:76)"`
+		Day     int32      `parquet:"day,date"`
+		Created *time.Time `parquet:"created"`
+		Tags    []string   `parquet:"tags"`
+		Address address    `parquet:"address"`
+	}
+	buffer := new(bytes.Buffer)
+	count, err := Write(buffer, FromDriverRows(newTestRows(t)), Parquet)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, 2, count)
+	file, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, column := range []string{"amount", "day"} {
+		field, ok := file.Schema().Lookup(column)
+		if assert.True(t, ok, column) {
+			assert.NotNil(t, field.Node.Type().LogicalType(), column)
+		}
+	}
+	assert.EqualValues(t, []string{"id", "name", "amount", "day", "created", "tags", "address"}, columnNames(file.Schema().Fields()))
+	records, err := parquet.Read[record](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if !assert.Nil(t, err) {
+		return
+	}
+	created := time.Date(2023, 4, 5, 6, 7, 8, 123000000, time.UTC)
+	day := int32(created.Unix() / secondsPerDay)
+	name, city := "a,b", "Austin"
+	expect := []record{
+		{ID: 1, Name: &name, Amount: decimalOf(t, "0.25", 38), Day: day, Created: &created, Tags: []string{"x", "y"}, Address: address{City: &city, Zip: 78701, Since: day}},
+		{ID: 2, Amount: decimalOf(t, "3", 38), Day: day, Tags: []string{}, Address: address{}},
+	}
+	if !assert.Len(t, records, len(expect)) {
+		return
+	}
+	for i := range expect {
+		if expect[i].Created != nil && records[i].Created != nil {
+			assert.True(t, expect[i].Created.Equal(*records[i].Created))
+			expect[i].Created = records[i].Created
+		}
+		if len(records[i].Tags) == 0 {
+			records[i].Tags = []string{}
+		}
+		assert.EqualValues(t, expect[i], records[i])
+	}
+}
+
+func TestPutDecimal(t *testing.T) {
+	var testCases = []struct {
+		description string
+		value       *big.Rat
+		scale       int
+		size        int
+		expect      []byte
+		expectErr   bool
+	}{
+		{description: "positive", value: big.NewRat(1, 4), scale: 2, size: 2, expect: []byte{0, 25}},
+		{description: "negative", value: big.NewRat(-1, 4), scale: 2, size: 2, expect: []byte{0xff, 0xe7}},
+		{description: "rounded", value: big.NewRat(1, 3), scale: 2, size: 2, expect: []byte{0, 33}},
+		{description: "out of range", value: big.NewRat(32768, 1), scale: 0, size: 2, expectErr: true},
+		{description: "min value", value: big.NewRat(-32768, 1), scale: 0, size: 2, expect: []byte{0x80, 0}},
+	}
+	for _, testCase := range testCases {
+		actual := make([]byte, testCase.size)
+		err := putDecimal(actual, testCase.value, testCase.scale)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if assert.Nil(t, err, testCase.description) {
+			assert.EqualValues(t, testCase.expect, actual, testCase.description)
+		}
+	}
+}
+
+func decimalOf(t *testing.T, value string, scale int) [32]byte {
+	var result [32]byte
+	rat, _ := new(big.Rat).SetString(value)
+	assert.Nil(t, putDecimal(result[:], rat, scale))
+	return result
+}
+
+func columnNames(fields []parquet.Field) []string {
+	var result []string
+	for _, field := range fields {
+		result = append(result, field.Name())
+	}
+	return result
+}
@@ -0,0 +1,276 @@
+package export
+
+import (
+	"fmt"
+	"github.com/parquet-go/parquet-go"
+	"github.com/viant/bigquery/internal/wire"
+	"io"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	numericPrecision    = 38
+	numericSize         = 16
+	bigNumericPrecision = 76
+	bigNumericSize      = 32
+	secondsPerDay       = 24 * 60 * 60
+)
+
+var int32Type = reflect.TypeOf(int32(0))
+
+// parquetType represents Parquet row type derived from driver scan type, node defines Parquet schema of the row type,
+// assign copies scanned value into the row type
+type parquetType struct {
+	rType  reflect.Type
+	node   parquet.Node
+	assign func(dest, source reflect.Value) error
+}
+
+// writeParquet writes rows as Parquet file with schema derived from column scan and data types
+func writeParquet(writer io.Writer, source Source, columns []*Column) (int, error) {
+	var names = make([]string, len(columns))
+	var types = make([]*parquetType, len(columns))
+	for i, column := range columns {
+		aType, err := newParquetType(column.ScanType, column.Type)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create parquet type for column: %v, %w", column.Name, err)
+		}
+		names[i] = column.Name
+		types[i] = aType
+	}
+	rowType, root := newParquetGroup(names, types)
+	row := reflect.New(rowType)
+	parquetWriter := parquet.NewWriter(writer, parquet.NewSchema("row", root))
+	count, err := readRows(source, len(columns), func(values []interface{}) error {
+		record := row.Elem()
+		for i, value := range values {
+			if err := types[i].assign(record.Field(i), reflect.ValueOf(value)); err != nil {
+				return fmt.Errorf("failed to assign column: %v, %w", columns[i].Name, err)
+			}
+		}
+		return parquetWriter.Write(row.Interface())
+	})
+	if err != nil {
+		return count, err
+	}
+	return count, parquetWriter.Close()
+}
+
+// newParquetType returns Parquet row type for scan type: NULLABLE pointers become optional fields,
+// slices repeated fields, RECORD structs groups, NUMERIC/BIGNUMERIC values decimals and DATE values dates
+func newParquetType(rType reflect.Type, dataType string) (*parquetType, error) {
+	switch rType {
+	case timeType:
+		if wire.ElementType(dataType) == "DATE" {
+			return &parquetType{rType: int32Type, node: parquet.Date(), assign: assignDate}, nil
+		}
+		return &parquetType{rType: rType, node: parquet.Timestamp(parquet.Nanosecond), assign: assignValue}, nil
+	case ratType:
+		return newParquetDecimalType(dataType), nil
+	case bytesType:
+		return &parquetType{rType: rType, node: parquet.Leaf(parquet.ByteArrayType), assign: assignValue}, nil
+	}
+	switch rType.Kind() {
+	case reflect.Ptr:
+		elem, err := newParquetType(rType.Elem(), dataType)
+		if err != nil {
+			return nil, err
+		}
+		return &parquetType{rType: reflect.PtrTo(elem.rType), node: parquet.Optional(elem.node), assign: func(dest, source reflect.Value) error {
+			source, ok := valueOf(interfaceOf(source))
+			if !ok {
+				dest.Set(reflect.Zero(dest.Type()))
+				return nil
+			}
+			value := reflect.New(elem.rType)
+			if err := elem.assign(value.Elem(), source); err != nil {
+				return err
+			}
+			dest.Set(value)
+			return nil
+		}}, nil
+	case reflect.Bool:
+		return &parquetType{rType: rType, node: parquet.Leaf(parquet.BooleanType), assign: assignValue}, nil
+	case reflect.String:
+		return &parquetType{rType: rType, node: parquet.String(), assign: assignValue}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &parquetType{rType: rType, node: parquet.Int(rType.Bits()), assign: assignValue}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &parquetType{rType: rType, node: parquet.Uint(rType.Bits()), assign: assignValue}, nil
+	case reflect.Float32:
+		return &parquetType{rType: rType, node: parquet.Leaf(parquet.FloatType), assign: assignValue}, nil
+	case reflect.Float64:
+		return &parquetType{rType: rType, node: parquet.Leaf(parquet.DoubleType), assign: assignValue}, nil
+	case reflect.Slice:
+		elem, err := newParquetType(rType.Elem(), dataType)
+		if err != nil {
+			return nil, err
+		}
+		return &parquetType{rType: reflect.SliceOf(elem.rType), node: parquet.Repeated(elem.node), assign: func(dest, source reflect.Value) error {
+			source, ok := valueOf(interfaceOf(source))
+			if !ok {
+				dest.Set(reflect.Zero(dest.Type()))
+				return nil
+			}
+			items := reflect.MakeSlice(dest.Type(), source.Len(), source.Len())
+			for i := 0; i < source.Len(); i++ {
+				if err := elem.assign(items.Index(i), source.Index(i)); err != nil {
+					return err
+				}
+			}
+			dest.Set(items)
+			return nil
+		}}, nil
+	case reflect.Struct:
+		return newParquetGroupType(rType, dataType)
+	}
+	return nil, fmt.Errorf("unsupported type: %v", rType)
+}
+
+// newParquetDecimalType returns DECIMAL type, NUMERIC and BIGNUMERIC values are stored as fixed length unscaled integers
+func newParquetDecimalType(dataType string) *parquetType {
+	scale, precision, size := wire.NumericScale, numericPrecision, numericSize
+	if wire.ElementType(dataType) == "BIGNUMERIC" {
+		scale, precision, size = wire.BigNumericScale, bigNumericPrecision, bigNumericSize
+	}
+	node := parquet.Decimal(scale, precision, parquet.FixedLenByteArrayType(size))
+	return &parquetType{rType: reflect.ArrayOf(size, reflect.TypeOf(byte(0))), node: node, assign: func(dest, source reflect.Value) error {
+		source, ok := valueOf(interfaceOf(source))
+		if !ok {
+			dest.Set(reflect.Zero(dest.Type()))
+			return nil
+		}
+		rat := source.Interface().(big.Rat)
+		return putDecimal(dest.Slice(0, dest.Len()).Bytes(), &rat, scale)
+	}}
+}
+
+// newParquetGroupType returns group type for RECORD struct, field data types are taken from STRUCT<name T, ...> data type
+func newParquetGroupType(rType reflect.Type, dataType string) (*parquetType, error) {
+	var names []string
+	var types []*parquetType
+	var indexes []int
+	dataTypes := fieldTypes(dataType)
+	for i := 0; i < rType.NumField(); i++ {
+		field := rType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := fieldName(field)
+		aType, err := newParquetType(field.Type, dataTypes[strings.ToLower(name)])
+		if err != nil {
+			return nil, fmt.Errorf("failed to create parquet type for field: %v, %w", field.Name, err)
+		}
+		names = append(names, name)
+		types = append(types, aType)
+		indexes = append(indexes, i)
+	}
+	groupType, node := newParquetGroup(names, types)
+	return &parquetType{rType: groupType, node: node, assign: func(dest, source reflect.Value) error {
+		source, ok := valueOf(interfaceOf(source))
+		if !ok {
+			dest.Set(reflect.Zero(dest.Type()))
+			return nil
+		}
+		for i, aType := range types {
+			if err := aType.assign(dest.Field(i), source.Field(indexes[i])); err != nil {
+				return fmt.Errorf("failed to assign field: %v, %w", source.Type().Field(indexes[i]).Name, err)
+			}
+		}
+		return nil
+	}}, nil
+}
+
+// newParquetGroup returns row struct type and group node, struct fields use generated names as column names may not be valid Go identifiers
+func newParquetGroup(names []string, types []*parquetType) (reflect.Type, parquet.Node) {
+	var fields = make([]reflect.StructField, len(types))
+	var nodes = make([]parquet.Field, len(types))
+	for i, aType := range types {
+		fields[i] = reflect.StructField{Name: fmt.Sprintf("F%d", i), Type: aType.rType}
+		nodes[i] = &groupField{Node: aType.node, name: names[i], index: i}
+	}
+	rType := reflect.StructOf(fields)
+	return rType, &groupNode{rType: rType, fields: nodes}
+}
+
+// groupNode represents Parquet group of row struct fields, unlike parquet.Group it keeps fields order
+type groupNode struct {
+	parquet.Group
+	rType  reflect.Type
+	fields []parquet.Field
+}
+
+func (g *groupNode) Fields() []parquet.Field { return g.fields }
+
+func (g *groupNode) GoType() reflect.Type { return g.rType }
+
+// groupField represents row struct field
+type groupField struct {
+	parquet.Node
+	name  string
+	index int
+}
+
+func (f *groupField) Name() string { return f.name }
+
+func (f *groupField) Value(base reflect.Value) reflect.Value {
+	for base.Kind() == reflect.Ptr || base.Kind() == reflect.Interface {
+		if base.IsNil() {
+			return reflect.Value{}
+		}
+		base = base.Elem()
+	}
+	return base.Field(f.index)
+}
+
+// putDecimal writes value rounded to scale as big endian two's complement unscaled integer
+func putDecimal(dest []byte, value *big.Rat, scale int) error {
+	unscaled, _ := new(big.Int).SetString(strings.Replace(value.FloatString(scale), ".", "", 1), 10)
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(len(dest)*8-1))
+	if unscaled.CmpAbs(limit) > 0 || unscaled.Cmp(limit) == 0 {
+		return fmt.Errorf("decimal value out of range: %v", value.FloatString(scale))
+	}
+	if unscaled.Sign() < 0 {
+		unscaled.Add(unscaled, new(big.Int).Lsh(limit, 1))
+	}
+	unscaled.FillBytes(dest)
+	return nil
+}
+
+// assignDate assigns DATE as number of days since unix epoch
+func assignDate(dest, source reflect.Value) error {
+	source, ok := valueOf(interfaceOf(source))
+	if !ok {
+		dest.Set(reflect.Zero(dest.Type()))
+		return nil
+	}
+	ts := source.Interface().(time.Time)
+	day := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.UTC)
+	dest.SetInt(day.Unix() / secondsPerDay)
+	return nil
+}
+
+// assignValue assigns basic value converting it to the destination type
+func assignValue(dest, source reflect.Value) error {
+	source, ok := valueOf(interfaceOf(source))
+	if !ok {
+		dest.Set(reflect.Zero(dest.Type()))
+		return nil
+	}
+	if !source.Type().ConvertibleTo(dest.Type()) {
+		return fmt.Errorf("unable to convert %v to %v", source.Type(), dest.Type())
+	}
+	dest.Set(source.Convert(dest.Type()))
+	return nil
+}
+
+// interfaceOf returns value held by reflect value, unwrapping interfaces
+func interfaceOf(value reflect.Value) interface{} {
+	if !value.IsValid() {
+		return nil
+	}
+	return value.Interface()
+}
@@ -0,0 +1,128 @@
+package export
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+var interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// Column represents exported column
+type Column struct {
+	Name string
+	// Type is BigQuery type name i.e. STRING, DATE or RECORD
+	Type string
+	// ScanType is driver scan type, RECORD columns use struct types with json tagged fields
+	ScanType reflect.Type
+	Nullable bool
+}
+
+// Source represents typed rows source
+type Source interface {
+	// Columns returns source columns
+	Columns() ([]*Column, error)
+	// Next reads next row into values, returns false when there are no more rows
+	Next(values []interface{}) (bool, error)
+}
+
+// FromRows returns source reading database/sql rows
+func FromRows(rows *sql.Rows) Source {
+	return &sqlSource{rows: rows}
+}
+
+// FromDriverRows returns source reading driver rows i.e. *bigquery.Rows
+func FromDriverRows(rows driver.Rows) Source {
+	return &driverSource{rows: rows}
+}
+
+type sqlSource struct {
+	rows     *sql.Rows
+	pointers []interface{}
+	values   []reflect.Value
+}
+
+// Columns returns columns defined by column types
+func (s *sqlSource) Columns() ([]*Column, error) {
+	columnTypes, err := s.rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	var result = make([]*Column, len(columnTypes))
+	s.pointers = make([]interface{}, len(columnTypes))
+	s.values = make([]reflect.Value, len(columnTypes))
+	for i, columnType := range columnTypes {
+		column := &Column{Name: columnType.Name(), Type: columnType.DatabaseTypeName(), ScanType: columnType.ScanType()}
+		if column.ScanType == nil {
+			column.ScanType = interfaceType
+		}
+		column.Nullable, _ = columnType.Nullable()
+		result[i] = column
+		s.values[i] = reflect.New(column.ScanType)
+		s.pointers[i] = s.values[i].Interface()
+	}
+	return result, nil
+}
+
+// Next scans next row into scan type values
+func (s *sqlSource) Next(values []interface{}) (bool, error) {
+	if s.pointers == nil {
+		if _, err := s.Columns(); err != nil {
+			return false, err
+		}
+	}
+	if !s.rows.Next() {
+		return false, s.rows.Err()
+	}
+	if err := s.rows.Scan(s.pointers...); err != nil {
+		return false, err
+	}
+	for i, value := range s.values {
+		values[i] = value.Elem().Interface()
+	}
+	return true, nil
+}
+
+type driverSource struct {
+	rows   driver.Rows
+	values []driver.Value
+}
+
+// Columns returns columns defined by driver column type interfaces
+func (s *driverSource) Columns() ([]*Column, error) {
+	names := s.rows.Columns()
+	var result = make([]*Column, len(names))
+	for i, name := range names {
+		column := &Column{Name: name, ScanType: interfaceType}
+		if typed, ok := s.rows.(driver.RowsColumnTypeScanType); ok {
+			column.ScanType = typed.ColumnTypeScanType(i)
+		}
+		if typed, ok := s.rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+			column.Type = typed.ColumnTypeDatabaseTypeName(i)
+		}
+		if typed, ok := s.rows.(driver.RowsColumnTypeNullable); ok {
+			column.Nullable, _ = typed.ColumnTypeNullable(i)
+		}
+		result[i] = column
+	}
+	return result, nil
+}
+
+// Next reads next driver row
+func (s *driverSource) Next(values []interface{}) (bool, error) {
+	if s.values == nil {
+		s.values = make([]driver.Value, len(s.rows.Columns()))
+	}
+	if err := s.rows.Next(s.values); err != nil {
+		if err == io.EOF {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read row: %w", err)
+	}
+	for i, value := range s.values {
+		values[i] = value
+	}
+	return true, nil
+}
@@ -1,6 +1,14 @@
 package bigquery
 
-import "google.golang.org/api/bigquery/v2"
+import (
+	"fmt"
+	"github.com/viant/bigquery/internal/hint"
+	"google.golang.org/api/bigquery/v2"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
 
 const (
 	dsnProjectID = "$ProjectID"
@@ -8,8 +16,82 @@ const (
 	dsnLocation  = "$Location"
 )
 
-//queryHint represents query hint struct
+// queryHint represents query hint struct
 type queryHint struct {
 	bigquery.JobConfigurationQuery
-	ExpandDSN bool //Expand the following variables $ProjectID, $DatasetID, $Location
+	ExpandDSN bool              //Expand the following variables $ProjectID, $DatasetID, $Location
+	Labels    map[string]string //Job labels merged with DSN labels
+	//Destination query result table in [project.][dataset.]table format
+	Destination string
+	//Partition destination table time partitioning in <HOUR|DAY|MONTH|YEAR>[:field] format
+	Partition string
+	//ClusterBy destination table clustering fields
+	ClusterBy []string
+	//MaxBytesBilled limits bytes billed, the job fails without being billed if the limit is exceeded
+	MaxBytesBilled int64
+	//Timeout job timeout in time.Duration format, i.e. 30s, BigQuery attempts to stop the job once it expires
+	Timeout string
+	//DryRun validates the query without running it
+	DryRun bool
+}
+
+// applyJobOptions applies job level hint options
+func (h *queryHint) applyJobOptions(job *bigquery.Job) error {
+	if h.MaxBytesBilled > 0 {
+		h.MaximumBytesBilled = h.MaxBytesBilled
+	}
+	if h.Timeout != "" {
+		timeout, err := time.ParseDuration(h.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid hint Timeout: %v, %w", h.Timeout, err)
+		}
+		job.Configuration.JobTimeoutMs = timeout.Milliseconds()
+	}
+	job.Configuration.DryRun = h.DryRun
+	return nil
+}
+
+// Hint represents typed query hint, String renders it as compact /*+ key=value +*/ comment, i.e.
+//
+//	SQL := bigquery.Hint{Labels: map[string]string{"team": "ads"}, MaxBytesBilled: 1 << 30}.String() + " SELECT * FROM t"
+type Hint struct {
+	Labels         map[string]string
+	MaxBytesBilled int64
+	Timeout        time.Duration
+	DryRun         bool
+	Priority       string
+	//Destination query result table in [project.][dataset.]table format
+	Destination string
+}
+
+// String returns compact hint comment, empty hint returns empty string
+func (h Hint) String() string {
+	var pairs []string
+	if len(h.Labels) > 0 {
+		var items = make([]string, 0, len(h.Labels))
+		for k, v := range h.Labels {
+			items = append(items, k+":"+v)
+		}
+		sort.Strings(items)
+		pairs = append(pairs, "Labels="+hint.Quote(strings.Join(items, ",")))
+	}
+	if h.MaxBytesBilled > 0 {
+		pairs = append(pairs, "MaxBytesBilled="+strconv.FormatInt(h.MaxBytesBilled, 10))
+	}
+	if h.Timeout > 0 {
+		pairs = append(pairs, "Timeout="+h.Timeout.String())
+	}
+	if h.DryRun {
+		pairs = append(pairs, "DryRun=true")
+	}
+	if h.Priority != "" {
+		pairs = append(pairs, "Priority="+hint.Quote(h.Priority))
+	}
+	if h.Destination != "" {
+		pairs = append(pairs, "Destination="+hint.Quote(h.Destination))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "/*+ " + strings.Join(pairs, " ") + " +*/"
 }
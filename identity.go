@@ -0,0 +1,118 @@
+package bigquery
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/viant/bigquery/internal/identity"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+)
+
+const defaultMaxIdentities = 64
+
+// WithTokenSource returns a context whose job submission, polling and page fetches use the token source
+// instead of the DSN credentials. Connections and the HTTP connection pool are still shared,
+// authenticated clients are cached per token source, thus a token source should be reused across calls and has to be comparable (i.e. a pointer),
+// calls with non comparable token source fail.
+func WithTokenSource(ctx context.Context, source oauth2.TokenSource) context.Context {
+	return identity.NewContext(ctx, identity.WithTokenSource(source))
+}
+
+// WithCredentialsJSON returns a context whose job submission, polling and page fetches use the credentials JSON
+// (i.e. service account key) instead of the DSN credentials, authenticated clients are cached per credentials
+func WithCredentialsJSON(ctx context.Context, data []byte) context.Context {
+	return identity.NewContext(ctx, identity.WithCredentialsJSON(data))
+}
+
+// contextService returns service of the identity carried by ctx, or the connection service otherwise
+func (s *sharedService) contextService(ctx context.Context, service *bigquery.Service) (*bigquery.Service, error) {
+	anIdentity := identity.FromContext(ctx)
+	if anIdentity == nil || s == nil || s.identities == nil {
+		return service, nil
+	}
+	if anIdentity.Err != nil {
+		return nil, fmt.Errorf("failed to create context credentials: %w", anIdentity.Err)
+	}
+	if result := s.identities.get(anIdentity.Key); result != nil {
+		return result, nil
+	}
+	result, err := s.newIdentityService(context.WithoutCancel(ctx), anIdentity)
+	if err != nil {
+		return nil, err
+	}
+	s.identities.put(anIdentity.Key, result)
+	return result, nil
+}
+
+// newIdentityService creates service authenticated with the identity credentials, sharing the base transport
+func (s *sharedService) newIdentityService(ctx context.Context, anIdentity *identity.Identity) (*bigquery.Service, error) {
+	source := anIdentity.TokenSource
+	if len(anIdentity.CredentialsJSON) > 0 {
+		credentials, err := google.CredentialsFromJSON(ctx, anIdentity.CredentialsJSON, s.scopes...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create context credentials: %w", err)
+		}
+		source = credentials.TokenSource
+	}
+	options := append(append([]option.ClientOption{}, s.options...), option.WithTokenSource(oauth2.ReuseTokenSource(nil, source)))
+	transport, err := htransport.NewTransport(ctx, s.base, options...)
+	if err != nil {
+		return nil, err
+	}
+	options = append(filterOptions(options, "option.withEndpoint"), option.WithHTTPClient(&http.Client{Transport: transport}))
+	return bigquery.NewService(ctx, options...)
+}
+
+// identityCache represents bounded, least recently used evicted cache of per identity services
+type identityCache struct {
+	mux   sync.Mutex
+	max   int
+	items map[interface{}]*list.Element
+	order *list.List
+}
+
+type identityEntry struct {
+	key     interface{}
+	service *bigquery.Service
+}
+
+func (c *identityCache) get(key interface{}) *bigquery.Service {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	element, ok := c.items[key]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*identityEntry).service
+}
+
+func (c *identityCache) put(key interface{}, service *bigquery.Service) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if element, ok := c.items[key]; ok {
+		element.Value.(*identityEntry).service = service
+		c.order.MoveToFront(element)
+		return
+	}
+	c.items[key] = c.order.PushFront(&identityEntry{key: key, service: service})
+	for c.order.Len() > c.max {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*identityEntry).key)
+	}
+}
+
+func newIdentityCache(max int) *identityCache {
+	if max <= 0 {
+		max = defaultMaxIdentities
+	}
+	return &identityCache{max: max, items: map[interface{}]*list.Element{}, order: list.New()}
+}
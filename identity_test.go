@@ -0,0 +1,132 @@
+package bigquery
+
+import (
+	"context"
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+)
+
+func TestWithTokenSource(t *testing.T) {
+	var mux sync.Mutex
+	var authorizations = map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.Lock()
+		authorizations[r.Method+" "+r.URL.Path] = r.Header.Get("Authorization")
+		mux.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/jobs"):
+			_, _ = w.Write([]byte(`{"jobReference": {"projectId": "p", "jobId": "j1"}, "status": {"state": "RUNNING"}}`))
+		case strings.HasSuffix(r.URL.Path, "/jobs/j1"):
+			_, _ = w.Write([]byte(`{"jobReference": {"projectId": "p", "jobId": "j1"}, "status": {"state": "DONE"}}`))
+		default:
+			_, _ = w.Write([]byte(`{"schema":{"fields":[{"name":"id","type":"STRING","mode":"NULLABLE"}]},"totalRows":"1","rows":[{"f":[{"v":"1"}]}],"jobComplete":true}`))
+		}
+	}))
+	defer server.Close()
+
+	aConnector := &connector{
+		cfg:     &Config{ProjectID: "p", Endpoint: server.URL},
+		options: []option.ClientOption{option.WithHTTPClient(server.Client())},
+	}
+	conn, err := aConnector.Connect(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer conn.Close()
+	tenant := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "tenant", TokenType: "Bearer"})
+	for i := 0; i < 2; i++ {
+		ctx := WithTokenSource(context.Background(), tenant)
+		stmt, err := conn.(*connection).PrepareContext(ctx, "SELECT 1 AS id")
+		if !assert.NoError(t, err) {
+			return
+		}
+		rows, err := stmt.(driver.StmtQueryContext).QueryContext(ctx, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		values := make([]driver.Value, 1)
+		assert.NoError(t, rows.Next(values))
+		assert.NoError(t, rows.Close())
+	}
+	assert.NoError(t, conn.(*connection).Ping(WithTokenSource(context.Background(), tenant)))
+	assert.Equal(t, 1, aConnector.shared.identities.order.Len())
+	assert.Contains(t, authorizations, "GET /projects/p/jobs")
+	assert.NotEmpty(t, authorizations)
+	for request, authorization := range authorizations {
+		assert.Equal(t, "Bearer tenant", authorization, request)
+	}
+
+	_, err = conn.(*connection).contextService(WithCredentialsJSON(context.Background(), []byte("{}")))
+	assert.Error(t, err)
+	_, err = conn.(*connection).contextService(WithTokenSource(context.Background(), tokenSourceFunc(func() (*oauth2.Token, error) {
+		return &oauth2.Token{AccessToken: "tenant"}, nil
+	})))
+	assert.Error(t, err, "non comparable token source")
+	_, err = conn.(*connection).contextService(WithTokenSource(context.Background(), nil))
+	assert.Error(t, err, "nil token source")
+}
+
+func TestIngestionStatement_ContextService(t *testing.T) {
+	aConnector := &connector{cfg: &Config{ProjectID: "p"}, options: []option.ClientOption{option.WithHTTPClient(http.DefaultClient)}}
+	conn, err := aConnector.Connect(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer conn.Close()
+	invalid := WithTokenSource(context.Background(), nil)
+	stmt, err := conn.(*connection).PrepareContext(invalid, "LOAD 'Reader:csv:r1' DATA INTO TABLE mytable")
+	if !assert.NoError(t, err, "credentials are resolved at execution") {
+		return
+	}
+	_, err = stmt.(driver.StmtExecContext).ExecContext(invalid, nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "token source was nil")
+	}
+}
+
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) { return f() }
+
+func TestIdentityCache(t *testing.T) {
+	var testCases = []struct {
+		description string
+		max         int
+		puts        []string
+		gets        []string
+		expect      []string
+	}{
+		{description: "within limit", max: 2, puts: []string{"a", "b"}, expect: []string{"a", "b"}},
+		{description: "least recently put evicted", max: 2, puts: []string{"a", "b", "c"}, expect: []string{"b", "c"}},
+		{description: "least recently used evicted", max: 2, puts: []string{"a", "b"}, gets: []string{"a"}, expect: []string{"a", "c"}},
+	}
+	for _, testCase := range testCases {
+		cache := newIdentityCache(testCase.max)
+		for _, key := range testCase.puts {
+			cache.put(key, &bigquery.Service{})
+		}
+		for _, key := range testCase.gets {
+			cache.get(key)
+		}
+		if len(testCase.gets) > 0 {
+			cache.put("c", &bigquery.Service{})
+		}
+		var actual []string
+		for _, key := range []string{"a", "b", "c"} {
+			if cache.get(key) != nil {
+				actual = append(actual, key)
+			}
+		}
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
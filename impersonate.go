@@ -0,0 +1,22 @@
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/option"
+)
+
+// impersonatedTokenSource returns token source of the impersonated service account, base options provide source credentials
+func (c *Config) impersonatedTokenSource(ctx context.Context, base ...option.ClientOption) (oauth2.TokenSource, error) {
+	result, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+		TargetPrincipal: c.Impersonate,
+		Scopes:          c.scopes(),
+		Delegates:       c.Delegates,
+	}, base...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to impersonate service account: %v, %w", c.Impersonate, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,104 @@
+package bigquery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/option"
+)
+
+// redirectTransport sends all requests to the test server
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (r *redirectTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+	request.URL.Scheme = r.target.Scheme
+	request.URL.Host = r.target.Host
+	return http.DefaultTransport.RoundTrip(request)
+}
+
+func TestConfig_ImpersonatedTokenSource(t *testing.T) {
+	var path string
+	var body struct {
+		Delegates []string `json:"delegates"`
+		Scope     []string `json:"scope"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"accessToken": "impersonated", "expireTime": "2099-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+	target, _ := url.Parse(server.URL)
+
+	cfg, err := ParseDSN("bigquery://p/us/ds?impersonate=sa@p.iam.gserviceaccount.com&delegates=d1@p.iam.gserviceaccount.com,d2@p.iam.gserviceaccount.com")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "sa@p.iam.gserviceaccount.com", cfg.Impersonate)
+	assert.Equal(t, []string{"d1@p.iam.gserviceaccount.com", "d2@p.iam.gserviceaccount.com"}, cfg.Delegates)
+
+	source, err := cfg.impersonatedTokenSource(context.Background(), option.WithHTTPClient(&http.Client{Transport: &redirectTransport{target: target}}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	token, err := source.Token()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "impersonated", token.AccessToken)
+	assert.Equal(t, "/v1/projects/-/serviceAccounts/sa@p.iam.gserviceaccount.com:generateAccessToken", path)
+	assert.Equal(t, []string{"projects/-/serviceAccounts/d1@p.iam.gserviceaccount.com", "projects/-/serviceAccounts/d2@p.iam.gserviceaccount.com"}, body.Delegates)
+	assert.Contains(t, body.Scope, "https://www.googleapis.com/auth/bigquery")
+}
+
+func TestParseDSN_ExternalAccount(t *testing.T) {
+	location := filepath.Join(t.TempDir(), "federation.json")
+	external := `{"type": "external_account", "audience": "//iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/p/providers/aws", "subject_token_type": "urn:ietf:params:aws:token-type:aws4_request", "token_url": "https://sts.googleapis.com/v1/token"}`
+	serviceAccount := filepath.Join(t.TempDir(), "sa.json")
+	if !assert.NoError(t, os.WriteFile(location, []byte(external), 0600)) || !assert.NoError(t, os.WriteFile(serviceAccount, []byte(`{"type": "service_account"}`), 0600)) {
+		return
+	}
+	var testCases = []struct {
+		description string
+		dsn         string
+		expectJSON  string
+		hasError    bool
+	}{
+		{
+			description: "external account",
+			dsn:         "bigquery://p/us/ds?externalAccount=" + url.QueryEscape(location),
+			expectJSON:  external,
+		},
+		{
+			description: "not an external account",
+			dsn:         "bigquery://p/us/ds?externalAccount=" + url.QueryEscape(serviceAccount),
+			hasError:    true,
+		},
+		{
+			description: "conflicting credentials",
+			dsn:         "bigquery://p/us/ds?externalAccount=" + url.QueryEscape(location) + "&credURL=" + url.QueryEscape(serviceAccount),
+			hasError:    true,
+		},
+	}
+	for _, testCase := range testCases {
+		cfg, err := ParseDSN(testCase.dsn)
+		if testCase.hasError {
+			assert.Error(t, err, testCase.description)
+			continue
+		}
+		if assert.NoError(t, err, testCase.description) {
+			assert.JSONEq(t, testCase.expectJSON, string(cfg.CredentialJSON), testCase.description)
+			assert.True(t, cfg.hasCred(), testCase.description)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package command
+
+import (
+	"strings"
+)
+
+// Kind represents command kind
+type Kind string
+
+const (
+	//KindAttachJob means attaching to an existing job results
+	KindAttachJob = Kind("ATTACH JOB")
+	//KindShowDatasets means listing project datasets
+	KindShowDatasets = Kind("SHOW DATASETS")
+	//KindShowTables means listing dataset tables
+	KindShowTables = Kind("SHOW TABLES")
+	//KindDescribe means describing table columns
+	KindDescribe = Kind("DESCRIBE")
+)
+
+type (
+	// Command represents a driver pseudo statement
+	Command struct {
+		Kind Kind
+		Job  *Job
+		//Target project for SHOW DATASETS, [project.]dataset for SHOW TABLES or [project.][dataset.]table for DESCRIBE
+		Target string
+	}
+
+	// Job represents a job reference
+	Job struct {
+		ProjectID string
+		Location  string
+		ID        string
+	}
+)
+
+// Init initialises unspecified job reference values with defaults
+func (j *Job) Init(projectID, location string) {
+	if j.ProjectID == "" {
+		j.ProjectID = projectID
+	}
+	if j.Location == "" {
+		j.Location = location
+	}
+}
+
+// Is returns true if SQL is a driver pseudo statement
+func Is(SQL string) bool {
+	normalizedSQL := strings.ToUpper(strings.TrimSpace(SQL))
+	for _, prefix := range []string{"ATTACH ", "SHOW ", "DESCRIBE "} {
+		if strings.HasPrefix(normalizedSQL, prefix) {
+			return true
+		}
+	}
+	return false
+}
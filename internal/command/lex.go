@@ -0,0 +1,31 @@
+package command
+
+import (
+	smatcher "github.com/viant/bigquery/internal/ingestion/matcher"
+	"github.com/viant/parsly"
+	"github.com/viant/parsly/matcher"
+	"github.com/viant/parsly/matcher/option"
+)
+
+const (
+	whitespace = iota
+	attachJobKeyword
+	jobReference
+	showDatasetsKeyword
+	showTablesKeyword
+	describeKeyword
+	inKeyword
+	selector
+)
+
+var whitespaceMatcher = parsly.NewToken(whitespace, "WHITESPACE", matcher.NewWhiteSpace())
+
+var attachJobMatcher = parsly.NewToken(attachJobKeyword, "ATTACH JOB", matcher.NewSpacedFragment("ATTACH JOB", &option.Case{Sensitive: false}))
+
+var jobReferenceMatcher = parsly.NewToken(jobReference, "'[project:][location.]jobID'", matcher.NewByteQuote('\'', '\\'))
+
+var showDatasetsMatcher = parsly.NewToken(showDatasetsKeyword, "SHOW DATASETS", matcher.NewSpacedFragment("SHOW DATASETS", &option.Case{Sensitive: false}))
+var showTablesMatcher = parsly.NewToken(showTablesKeyword, "SHOW TABLES", matcher.NewSpacedFragment("SHOW TABLES", &option.Case{Sensitive: false}))
+var describeMatcher = parsly.NewToken(describeKeyword, "DESCRIBE", matcher.NewFragment("DESCRIBE", &option.Case{Sensitive: false}))
+var inKeywordMatcher = parsly.NewToken(inKeyword, "IN", matcher.NewFragment("IN", &option.Case{Sensitive: false}))
+var selectorMatcher = parsly.NewToken(selector, "[project.][dataset.]table", smatcher.NewSelector())
@@ -0,0 +1,127 @@
+package command
+
+import (
+	"fmt"
+	"github.com/viant/parsly"
+	"strings"
+)
+
+// Parse parses a driver pseudo statement
+func Parse(SQL string) (*Command, error) {
+	SQL = strings.TrimSpace(SQL)
+	cursor := parsly.NewCursor("", []byte(SQL), 0)
+	match := cursor.MatchAny(attachJobMatcher, showDatasetsMatcher, showTablesMatcher, describeMatcher)
+	var result *Command
+	var err error
+	switch match.Code {
+	case attachJobKeyword:
+		result, err = parseAttachJob(cursor, SQL)
+	case showDatasetsKeyword:
+		result, err = parseShow(cursor, KindShowDatasets)
+	case showTablesKeyword:
+		result, err = parseShow(cursor, KindShowTables)
+	case describeKeyword:
+		result, err = parseDescribe(cursor, SQL)
+	default:
+		return nil, fmt.Errorf("%w, current token:%s", cursor.NewError(attachJobMatcher, showDatasetsMatcher, showTablesMatcher, describeMatcher), SQL)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = matchEnd(cursor); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// parseAttachJob parses ATTACH JOB '[project:][location.]jobID'
+func parseAttachJob(cursor *parsly.Cursor, SQL string) (*Command, error) {
+	result := &Command{Kind: KindAttachJob}
+	match := cursor.MatchAfterOptional(whitespaceMatcher, jobReferenceMatcher)
+	if match.Code != jobReference {
+		return nil, fmt.Errorf("%w, current token:%s", cursor.NewError(jobReferenceMatcher), SQL)
+	}
+	text := match.Text(cursor)
+	var err error
+	if result.Job, err = ParseJob(text[1 : len(text)-1]); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// parseShow parses SHOW DATASETS [IN project] and SHOW TABLES [IN [project.]dataset]
+func parseShow(cursor *parsly.Cursor, kind Kind) (*Command, error) {
+	result := &Command{Kind: kind}
+	if match := cursor.MatchAfterOptional(whitespaceMatcher, inKeywordMatcher); match.Code != inKeyword {
+		return result, nil
+	}
+	match := cursor.MatchOne(whitespaceMatcher)
+	if match.Code != whitespace {
+		return nil, fmt.Errorf("%w, current token:%s", cursor.NewError(whitespaceMatcher), cursor.Input)
+	}
+	match = cursor.MatchAfterOptional(whitespaceMatcher, selectorMatcher)
+	if match.Code != selector {
+		return nil, fmt.Errorf("%w, current token:%s", cursor.NewError(selectorMatcher), cursor.Input)
+	}
+	result.Target = strings.Trim(match.Text(cursor), "`")
+	return result, nil
+}
+
+// parseDescribe parses DESCRIBE [project.][dataset.]table
+func parseDescribe(cursor *parsly.Cursor, SQL string) (*Command, error) {
+	match := cursor.MatchOne(whitespaceMatcher)
+	if match.Code != whitespace {
+		return nil, fmt.Errorf("%w, current token:%s", cursor.NewError(whitespaceMatcher), SQL)
+	}
+	match = cursor.MatchAfterOptional(whitespaceMatcher, selectorMatcher)
+	if match.Code != selector {
+		return nil, fmt.Errorf("%w, current token:%s", cursor.NewError(selectorMatcher), SQL)
+	}
+	return &Command{Kind: KindDescribe, Target: strings.Trim(match.Text(cursor), "`")}, nil
+}
+
+// ParseJob parses job reference in [project:][location.]jobID format
+func ParseJob(text string) (*Job, error) {
+	result := &Job{}
+	if index := strings.LastIndex(text, ":"); index != -1 {
+		result.ProjectID = text[:index]
+		text = text[index+1:]
+	}
+	if index := strings.Index(text, "."); index != -1 {
+		result.Location = text[:index]
+		text = text[index+1:]
+	}
+	result.ID = text
+	if !isValidJobID(result.ID) {
+		return nil, fmt.Errorf("invalid job ID: %q", result.ID)
+	}
+	return result, nil
+}
+
+// isValidJobID checks if job ID contains only letters, numbers, underscores or dashes
+func isValidJobID(ID string) bool {
+	if ID == "" || len(ID) > 1024 {
+		return false
+	}
+	for _, c := range ID {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func matchEnd(cursor *parsly.Cursor) error {
+	match := cursor.MatchOne(whitespaceMatcher)
+	switch match.Code {
+	case whitespace, parsly.EOF:
+		if cursor.HasMore() {
+			return fmt.Errorf("unexpected sequence: %s", cursor.Input[cursor.Pos:])
+		}
+		return nil
+	default:
+		return fmt.Errorf("unexpected sequence: %s", cursor.Input[cursor.Pos:])
+	}
+}
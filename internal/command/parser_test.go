@@ -0,0 +1,104 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	var testCases = []struct {
+		description string
+		SQL         string
+		hasError    bool
+		expect      *Command
+	}{
+		{
+			description: "fully qualified job",
+			SQL:         "ATTACH JOB 'my-project:us.job_123-abc'",
+			expect:      &Command{Kind: KindAttachJob, Job: &Job{ProjectID: "my-project", Location: "us", ID: "job_123-abc"}},
+		},
+		{
+			description: "domain scoped project",
+			SQL:         "attach job 'example.com:my-project:EU.job_123'",
+			expect:      &Command{Kind: KindAttachJob, Job: &Job{ProjectID: "example.com:my-project", Location: "EU", ID: "job_123"}},
+		},
+		{
+			description: "job with location",
+			SQL:         "ATTACH  JOB  'us-central1.job_123' ",
+			expect:      &Command{Kind: KindAttachJob, Job: &Job{Location: "us-central1", ID: "job_123"}},
+		},
+		{
+			description: "job ID only",
+			SQL:         "ATTACH JOB 'job_123'",
+			expect:      &Command{Kind: KindAttachJob, Job: &Job{ID: "job_123"}},
+		},
+		{
+			description: "missing quotes",
+			SQL:         "ATTACH JOB job_123",
+			hasError:    true,
+		},
+		{
+			description: "invalid job ID",
+			SQL:         "ATTACH JOB 'us.job 123'",
+			hasError:    true,
+		},
+		{
+			description: "unexpected sequence",
+			SQL:         "ATTACH JOB 'job_123' LIMIT 10",
+			hasError:    true,
+		},
+		{
+			description: "show datasets",
+			SQL:         "show datasets",
+			expect:      &Command{Kind: KindShowDatasets},
+		},
+		{
+			description: "show datasets in project",
+			SQL:         "SHOW DATASETS IN my-project",
+			expect:      &Command{Kind: KindShowDatasets, Target: "my-project"},
+		},
+		{
+			description: "show tables",
+			SQL:         "SHOW  TABLES ",
+			expect:      &Command{Kind: KindShowTables},
+		},
+		{
+			description: "show tables in dataset",
+			SQL:         "SHOW TABLES IN `my-project.mydataset`",
+			expect:      &Command{Kind: KindShowTables, Target: "my-project.mydataset"},
+		},
+		{
+			description: "show tables without dataset",
+			SQL:         "SHOW TABLES IN",
+			hasError:    true,
+		},
+		{
+			description: "describe table",
+			SQL:         "DESCRIBE my-project.mydataset.mytable",
+			expect:      &Command{Kind: KindDescribe, Target: "my-project.mydataset.mytable"},
+		},
+		{
+			description: "describe without table",
+			SQL:         "DESCRIBE ",
+			hasError:    true,
+		},
+		{
+			description: "show unsupported object",
+			SQL:         "SHOW VIEWS",
+			hasError:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := Parse(testCase.SQL)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+	}
+}
@@ -1,12 +1,24 @@
 package exec
 
 import (
+	"context"
+	"errors"
+	"github.com/viant/bigquery/internal/logging"
+	"github.com/viant/bigquery/internal/trace"
+	"github.com/viant/bigquery/metric"
+	oteltrace "go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/googleapi"
 	"math/rand"
 	"net/http"
 	"time"
 )
 
+// IsNotFound returns true if err is API not found error
+func IsNotFound(err error) bool {
+	var apiError *googleapi.Error
+	return errors.As(err, &apiError) && apiError.Code == http.StatusNotFound
+}
+
 func shallRetry(err error) bool {
 	if apiError, ok := err.(*googleapi.Error); ok {
 		switch apiError.Code {
@@ -17,7 +29,7 @@ func shallRetry(err error) bool {
 	return false
 }
 
-//retrier represents abstraction holding sleep duration between retries (back-off)
+// retrier represents abstraction holding sleep duration between retries (back-off)
 type retrier struct {
 	Count      int
 	Initial    time.Duration
@@ -50,20 +62,49 @@ func (b *retrier) Pause() time.Duration {
 	return result
 }
 
-//newRetries creates a retrier
+// newRetries creates a retrier
 func newRetries() *retrier {
 	return &retrier{}
 }
 
-//RunWithRetries run with exp backoff retries on http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusBadGateway
-func RunWithRetries(f func() error, maxRetries int) (err error) {
+// RunWithRetries run with exp backoff retries on http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusBadGateway,
+// retries are recorded on the ctx span and logged with the ctx logger
+func RunWithRetries(ctx context.Context, f func() error, maxRetries int) (err error) {
 	aRetrier := newRetries()
+	span := oteltrace.SpanFromContext(ctx)
 	for i := 0; i < maxRetries; i++ {
 		err = f()
 		if !shallRetry(err) {
+			if i > 0 {
+				span.SetAttributes(trace.RetryAttemptsKey.Int(i + 1))
+			}
 			return err
 		}
-		time.Sleep(aRetrier.Pause())
+		if i == maxRetries-1 { //no more attempts, nothing to record or wait for
+			break
+		}
+		pause := aRetrier.Pause()
+		reason := retryReason(err)
+		metric.Add(metric.Retries, 1, metric.Reason(reason))
+		span.AddEvent("retry", oteltrace.WithAttributes(trace.RetryAttemptsKey.Int(i+1), trace.RetryReasonKey.String(reason)))
+		logging.FromContext(ctx).Retry(ctx, i+1, reason, pause, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pause):
+		}
 	}
+	span.SetAttributes(trace.RetryAttemptsKey.Int(maxRetries))
 	return err
 }
+
+// retryReason returns retried error reason, i.e. backendError
+func retryReason(err error) string {
+	if apiError, ok := err.(*googleapi.Error); ok {
+		if len(apiError.Errors) > 0 && apiError.Errors[0].Reason != "" {
+			return apiError.Errors[0].Reason
+		}
+		return http.StatusText(apiError.Code)
+	}
+	return err.Error()
+}
@@ -0,0 +1,64 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/bigquery/metric"
+	"google.golang.org/api/googleapi"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+type countingRecorder struct {
+	mux    sync.Mutex
+	counts map[string]float64
+}
+
+func (r *countingRecorder) Add(name string, value float64, labels map[string]string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.counts[name] += value
+}
+
+func (r *countingRecorder) Observe(name string, value float64, labels map[string]string) {}
+
+func TestIsNotFound(t *testing.T) {
+	assert.True(t, IsNotFound(&googleapi.Error{Code: http.StatusNotFound}))
+	assert.True(t, IsNotFound(fmt.Errorf("failed to get table: %w", &googleapi.Error{Code: http.StatusNotFound})))
+	assert.False(t, IsNotFound(&googleapi.Error{Code: http.StatusForbidden}))
+	assert.False(t, IsNotFound(errors.New("not found")))
+}
+
+func TestRunWithRetries(t *testing.T) {
+	unavailable := &googleapi.Error{Code: http.StatusServiceUnavailable}
+	var testCases = []struct {
+		description   string
+		errors        []error
+		maxRetries    int
+		expectErr     error
+		expectCalls   int
+		expectRetries float64
+	}{
+		{description: "success", errors: []error{nil}, maxRetries: 3, expectCalls: 1},
+		{description: "non retryable error", errors: []error{errors.New("invalid")}, maxRetries: 3, expectErr: errors.New("invalid"), expectCalls: 1},
+		{description: "retried then success", errors: []error{unavailable, nil}, maxRetries: 3, expectCalls: 2, expectRetries: 1},
+		{description: "last attempt is not recorded as retry", errors: []error{unavailable, unavailable}, maxRetries: 2, expectErr: unavailable, expectCalls: 2, expectRetries: 1},
+	}
+	defer metric.Set(nil)
+	for _, testCase := range testCases {
+		recorder := &countingRecorder{counts: map[string]float64{}}
+		metric.Set(recorder)
+		calls := 0
+		err := RunWithRetries(context.Background(), func() error {
+			err := testCase.errors[calls]
+			calls++
+			return err
+		}, testCase.maxRetries)
+		assert.Equal(t, testCase.expectErr, err, testCase.description)
+		assert.Equal(t, testCase.expectCalls, calls, testCase.description)
+		assert.Equal(t, testCase.expectRetries, recorder.counts[metric.Retries], testCase.description)
+	}
+}
@@ -4,36 +4,100 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/viant/bigquery/internal/logging"
+	"github.com/viant/bigquery/internal/trace"
+	"github.com/viant/bigquery/metric"
 	"google.golang.org/api/bigquery/v2"
+	"strings"
 	"time"
 )
 
 const (
 	//StatusDone status done
 	StatusDone = "DONE"
+
+	initialPollWait = 50 * time.Millisecond
+	maxPollWait     = 5 * time.Second
 )
 
-//WaitForJobCompletion waits for job completion
+// WaitForJobCompletion waits for job completion, cancelled ctx returns the last polled job with ctx error,
+// non retryable poll errors (i.e. job not found) are returned while retryable ones keep polling,
+// the wait is traced with a single span recording the number of polls
 func WaitForJobCompletion(ctx context.Context, service *bigquery.Service, projectID string, location, jobReferenceID string) (*bigquery.Job, error) {
+	ctx, span := trace.Start(ctx, "bigquery.waitJob", trace.ProjectKey.String(projectID), trace.LocationKey.String(location), trace.JobIDKey.String(jobReferenceID))
+	job, polls, err := waitForJobCompletion(ctx, service, projectID, location, jobReferenceID)
+	span.SetAttributes(trace.JobPollsKey.Int(polls))
+	trace.SetJob(span, job)
+	trace.End(span, err)
+	return job, err
+}
+
+// waitForJobCompletion polls job status with capped exponential backoff, it returns the last polled job and number of polls
+func waitForJobCompletion(ctx context.Context, service *bigquery.Service, projectID string, location, jobReferenceID string) (*bigquery.Job, int, error) {
 	var job *bigquery.Job
 	var err error
-	waitTime := 30 * time.Millisecond
+	started := time.Now()
+	waitTime := initialPollWait
+	polls := 0
 	for {
-		err = RunWithRetries(func() error {
+		var polled *bigquery.Job
+		polls++
+		err = RunWithRetries(ctx, func() error {
 			statusCall := service.Jobs.Get(projectID, jobReferenceID)
 			statusCall.Location(location)
-			job, err = statusCall.Context(ctx).Do()
+			polled, err = statusCall.Context(ctx).Do()
 			return err
 		}, 3)
-		if err == nil && job.Status.State == StatusDone {
+		switch {
+		case ctx.Err() != nil:
+			return job, polls, ctx.Err()
+		case err != nil && !shallRetry(err):
+			return job, polls, err
+		case err == nil:
+			job = polled
+		}
+		if job != nil && job.Status.State == StatusDone {
+			elapsed := time.Since(started)
+			recordCompletion(job, elapsed)
+			logging.FromContext(ctx).JobCompleted(ctx, JobKind(job), job, elapsed)
 			break
 		}
-		waitTime = (waitTime*2 + 1) % 1000
-		time.Sleep(waitTime)
+		select {
+		case <-ctx.Done():
+			return job, polls, ctx.Err()
+		case <-time.After(waitTime):
+		}
+		waitTime = min(waitTime*2, maxPollWait)
 	}
 	if job != nil && job.Status != nil && job.Status.ErrorResult != nil {
 		errors, _ := json.Marshal(job.Status.Errors)
-		return job, fmt.Errorf("%v: %s", job.Status.ErrorResult.Message, errors)
+		return job, polls, fmt.Errorf("%v: %s", job.Status.ErrorResult.Message, errors)
 	}
-	return job, err
+	return job, polls, err
+}
+
+// recordCompletion records job wait time and billed bytes
+func recordCompletion(job *bigquery.Job, elapsed time.Duration) {
+	kind := metric.Kind(JobKind(job))
+	metric.Observe(metric.JobWaitSeconds, elapsed.Seconds(), kind)
+	if stats := job.Statistics; stats != nil && stats.Query != nil && stats.Query.TotalBytesBilled > 0 {
+		metric.Add(metric.BytesBilled, float64(stats.Query.TotalBytesBilled), kind)
+	}
+}
+
+// JobKind returns lower case job kind, i.e. query, load, extract or copy
+func JobKind(job *bigquery.Job) string {
+	config := job.Configuration
+	switch {
+	case config == nil:
+	case config.JobType != "":
+		return strings.ToLower(config.JobType)
+	case config.Load != nil:
+		return "load"
+	case config.Extract != nil:
+		return "extract"
+	case config.Copy != nil:
+		return "copy"
+	}
+	return "query"
 }
@@ -0,0 +1,96 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+)
+
+func TestWaitForJobCompletion(t *testing.T) {
+	var testCases = []struct {
+		description string
+		responses   []string // status code and body separated by space, the last response repeats
+		timeout     time.Duration
+		expectState string
+		hasError    bool
+		maxCalls    int32
+	}{
+		{
+			description: "done after running",
+			responses:   []string{`200 {"status": {"state": "RUNNING"}}`, `200 {"status": {"state": "DONE"}}`},
+			expectState: "DONE",
+		},
+		{
+			description: "job failure returns job",
+			responses:   []string{`200 {"status": {"state": "DONE", "errorResult": {"message": "syntax error"}}}`},
+			expectState: "DONE",
+			hasError:    true,
+		},
+		{
+			description: "retryable poll error keeps polling",
+			responses:   []string{`503 {"error": {"code": 503, "message": "backend error"}}`, `200 {"status": {"state": "DONE"}}`},
+			expectState: "DONE",
+		},
+		{
+			description: "non retryable poll error returned",
+			responses:   []string{`404 {"error": {"code": 404, "message": "job not found"}}`},
+			hasError:    true,
+		},
+		{
+			description: "cancelled context returns last polled job, polls back off",
+			responses:   []string{`200 {"status": {"state": "RUNNING"}}`},
+			timeout:     400 * time.Millisecond,
+			expectState: "RUNNING",
+			hasError:    true,
+			maxCalls:    5,
+		},
+	}
+	for _, testCase := range testCases {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			index := int(atomic.AddInt32(&calls, 1)) - 1
+			if index >= len(testCase.responses) {
+				index = len(testCase.responses) - 1
+			}
+			var code int
+			var body string
+			_, _ = fmt.Sscanf(testCase.responses[index], "%d", &code)
+			body = testCase.responses[index][4:]
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(code)
+			_, _ = w.Write([]byte(body))
+		}))
+		service, err := bigquery.NewService(context.Background(), option.WithHTTPClient(server.Client()), option.WithEndpoint(server.URL))
+		if !assert.NoError(t, err, testCase.description) {
+			server.Close()
+			continue
+		}
+		ctx := context.Background()
+		if testCase.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, testCase.timeout)
+			defer cancel()
+		}
+		job, err := WaitForJobCompletion(ctx, service, "p", "us", "j1")
+		server.Close()
+		assert.Equal(t, testCase.hasError, err != nil, testCase.description)
+		if testCase.maxCalls > 0 {
+			assert.LessOrEqual(t, atomic.LoadInt32(&calls), testCase.maxCalls, testCase.description)
+		}
+		if testCase.expectState == "" {
+			assert.Nil(t, job, testCase.description)
+			continue
+		}
+		if assert.NotNil(t, job, testCase.description) {
+			assert.Equal(t, testCase.expectState, job.Status.State, testCase.description)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package hint
+
+import (
+	"encoding/json"
+	"fmt"
+	smatcher "github.com/viant/bigquery/internal/ingestion/matcher"
+	"github.com/viant/parsly"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Pair represents compact hint key=value pair
+type Pair struct {
+	Key   string
+	Value string
+}
+
+// IsJSON returns true if hint uses JSON syntax
+func IsJSON(text string) bool {
+	return strings.HasPrefix(strings.TrimSpace(text), "{")
+}
+
+// Decode decodes JSON or compact key=value hint into target struct pointer, unknown keys are reported as error
+func Decode(text string, target interface{}) error {
+	return decode(text, target, true)
+}
+
+// DecodeKnown decodes JSON or compact key=value hint into target struct pointer ignoring keys target does not define,
+// it is used when hint options are split across several targets
+func DecodeKnown(text string, target interface{}) error {
+	return decode(text, target, false)
+}
+
+// ValidateKeys returns an error if hint defines a key none of the target struct pointers defines,
+// it complements DecodeKnown when hint options are split across several targets
+func ValidateKeys(text string, targets ...interface{}) error {
+	var keys []string
+	if IsJSON(text) {
+		fields := map[string]json.RawMessage{}
+		if err := json.Unmarshal([]byte(text), &fields); err != nil {
+			return err
+		}
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+	} else {
+		pairs, err := Parse(text)
+		if err != nil {
+			return err
+		}
+		for _, pair := range pairs {
+			keys = append(keys, pair.Key)
+		}
+	}
+outer:
+	for _, key := range keys {
+		for _, target := range targets {
+			value := reflect.ValueOf(target)
+			if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+				return fmt.Errorf("invalid hint target: %T, expected struct pointer", target)
+			}
+			if lookupField(value.Elem(), key).IsValid() {
+				continue outer
+			}
+		}
+		return fmt.Errorf("unknown hint key: %v", key)
+	}
+	return nil
+}
+
+func decode(text string, target interface{}, strict bool) error {
+	if IsJSON(text) {
+		decoder := json.NewDecoder(strings.NewReader(text))
+		if strict {
+			decoder.DisallowUnknownFields()
+		}
+		return decoder.Decode(target)
+	}
+	pairs, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("invalid hint target: %T, expected struct pointer", target)
+	}
+	for _, pair := range pairs {
+		field := lookupField(value.Elem(), pair.Key)
+		if !field.IsValid() {
+			if strict {
+				return fmt.Errorf("unknown hint key: %v", pair.Key)
+			}
+			continue
+		}
+		if err = setValue(field, pair.Value); err != nil {
+			return fmt.Errorf("invalid hint %v value: %v, %w", pair.Key, pair.Value, err)
+		}
+	}
+	return nil
+}
+
+var stringLiteral = smatcher.NewStringLiteral()
+
+// Parse parses compact hint in key=value [key=value ...] format, values with spaces are double or single quoted
+func Parse(text string) ([]*Pair, error) {
+	var result []*Pair
+	for i := 0; i < len(text); {
+		if isSpace(text[i]) {
+			i++
+			continue
+		}
+		begin := i
+		for i < len(text) && isKey(text[i]) {
+			i++
+		}
+		if begin == i || i >= len(text) || text[i] != '=' {
+			return nil, fmt.Errorf("invalid hint: %v, expected key=value at %v", text, begin)
+		}
+		pair := &Pair{Key: text[begin:i]}
+		i++
+		begin = i
+		if i < len(text) && (text[i] == '"' || text[i] == '\'') {
+			cursor := parsly.NewCursor("", []byte(text), 0)
+			cursor.Pos = i
+			i += stringLiteral.Match(cursor)
+			value, err := unquote(text[begin:i])
+			if err != nil {
+				return nil, fmt.Errorf("invalid hint: %v, malformed %v value, %w", text, pair.Key, err)
+			}
+			pair.Value = value
+		} else {
+			for i < len(text) && !isSpace(text[i]) {
+				i++
+			}
+			pair.Value = text[begin:i]
+		}
+		result = append(result, pair)
+	}
+	return result, nil
+}
+
+// Quote returns value quoted if it can not be used as bare compact hint value
+func Quote(value string) string {
+	if value == "" || strings.ContainsAny(value, " \t\r\n\"'") || strings.Contains(value, blockEnd) {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+func unquote(value string) (string, error) {
+	if strings.HasPrefix(value, "'") {
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return "", fmt.Errorf("unterminated quote")
+		}
+		return strings.ReplaceAll(value[1:len(value)-1], `\'`, `'`), nil
+	}
+	return strconv.Unquote(value)
+}
+
+// lookupField returns struct field matching key case insensitively by name or json name,
+// embedded structs are searched after direct fields
+func lookupField(value reflect.Value, key string) reflect.Value {
+	rType := value.Type()
+	var embedded []int
+	for i := 0; i < rType.NumField(); i++ {
+		field := rType.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			embedded = append(embedded, i)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		if strings.EqualFold(field.Name, key) || (jsonName != "" && strings.EqualFold(jsonName, key)) {
+			return value.Field(i)
+		}
+	}
+	for _, i := range embedded {
+		if result := lookupField(value.Field(i), key); result.IsValid() {
+			return result
+		}
+	}
+	return reflect.Value{}
+}
+
+// setValue sets text value converted to the field type, slices use comma separated items and maps k:v,k:v pairs
+func setValue(value reflect.Value, text string) error {
+	if value.Type() == durationType {
+		duration, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(duration))
+		return nil
+	}
+	switch value.Kind() {
+	case reflect.Ptr:
+		item := reflect.New(value.Type().Elem())
+		if err := setValue(item.Elem(), text); err != nil {
+			return err
+		}
+		value.Set(item)
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Bool:
+		boolValue, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		value.SetBool(boolValue)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		intValue, err := strconv.ParseInt(text, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(intValue)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		uintValue, err := strconv.ParseUint(text, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(uintValue)
+	case reflect.Float32, reflect.Float64:
+		floatValue, err := strconv.ParseFloat(text, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(floatValue)
+	case reflect.Slice:
+		items := strings.Split(text, ",")
+		slice := reflect.MakeSlice(value.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setValue(slice.Index(i), strings.TrimSpace(item)); err != nil {
+				return err
+			}
+		}
+		value.Set(slice)
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported compact hint type: %v, use JSON hint", value.Type())
+		}
+		aMap := reflect.MakeMap(value.Type())
+		for _, item := range strings.Split(text, ",") {
+			index := strings.Index(item, ":")
+			if index == -1 {
+				return fmt.Errorf("invalid map entry: %v, expected key:value", item)
+			}
+			entry := reflect.New(value.Type().Elem()).Elem()
+			if err := setValue(entry, strings.TrimSpace(item[index+1:])); err != nil {
+				return err
+			}
+			aMap.SetMapIndex(reflect.ValueOf(strings.TrimSpace(item[:index])).Convert(value.Type().Key()), entry)
+		}
+		value.Set(aMap)
+	default:
+		return fmt.Errorf("unsupported compact hint type: %v, use JSON hint", value.Type())
+	}
+	return nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isKey(c byte) bool {
+	return c == '_' || c == '.' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
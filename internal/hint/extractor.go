@@ -1,16 +1,55 @@
 package hint
 
-import "strings"
+import (
+	"github.com/viant/bigquery/internal/sqlscan"
+	"strings"
+)
 
-// Extract extracts json formatted hint from sql statement
-func Extract(query string) string {
-	if index := strings.Index(query, "/*+"); index != -1 {
-		if end := strings.Index(query, "+*/"); end != -1 {
-			hint := strings.TrimSpace(query[index+3 : end])
-			if strings.HasPrefix(hint, "{") || strings.HasSuffix(hint, "}") {
-				return hint
-			}
+const (
+	blockBegin = "/*+"
+	blockEnd   = "+*/"
+)
+
+// Block represents hint block, Text holds trimmed content between /*+ and +*/ delimiters
+type Block struct {
+	Begin int
+	End   int
+	Text  string
+}
+
+// Find returns hint blocks of sql statement, string literals, quoted identifiers and regular comments are skipped
+func Find(SQL string) []*Block {
+	var result []*Block
+	for _, fragment := range sqlscan.Scan(SQL) {
+		if fragment.Code != sqlscan.Hint {
+			continue
 		}
+		text := SQL[fragment.Begin+len(blockBegin) : fragment.End-len(blockEnd)]
+		result = append(result, &Block{Begin: fragment.Begin, End: fragment.End, Text: strings.TrimSpace(text)})
+	}
+	return result
+}
+
+// Extract extracts the first hint from sql statement
+func Extract(SQL string) string {
+	if blocks := Find(SQL); len(blocks) > 0 {
+		return blocks[0].Text
 	}
 	return ""
 }
+
+// Remove removes hint blocks from sql statement
+func Remove(SQL string) string {
+	blocks := Find(SQL)
+	if len(blocks) == 0 {
+		return SQL
+	}
+	var result strings.Builder
+	offset := 0
+	for _, block := range blocks {
+		result.WriteString(SQL[offset:block.Begin])
+		offset = block.End
+	}
+	result.WriteString(SQL[offset:])
+	return result.String()
+}
@@ -0,0 +1,174 @@
+package hint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFind(t *testing.T) {
+	var testCases = []struct {
+		description string
+		SQL         string
+		expect      []string
+		removed     string
+	}{
+		{
+			description: "single hint",
+			SQL:         `SELECT /*+ {"UseLegacySql": true} +*/ 1`,
+			expect:      []string{`{"UseLegacySql": true}`},
+			removed:     "SELECT  1",
+		},
+		{
+			description: "multiple hints",
+			SQL:         "SELECT /*+ DryRun=true +*/ 1 /*+ Timeout=1s +*/",
+			expect:      []string{"DryRun=true", "Timeout=1s"},
+			removed:     "SELECT  1 ",
+		},
+		{
+			description: "hints in literals and comments are skipped",
+			SQL:         "SELECT '/*+ a=1 +*/', \"/*+ b=2 +*/\", `/*+ c=3 +*/` /* /*+ d=4 +*/ -- /*+ e=5 +*/\n",
+			removed:     "SELECT '/*+ a=1 +*/', \"/*+ b=2 +*/\", `/*+ c=3 +*/` /* /*+ d=4 +*/ -- /*+ e=5 +*/\n",
+		},
+		{
+			description: "quote inside hint",
+			SQL:         `LOAD 'Reader:csv:1' /*+ {"Quote": "\""} +*/ DATA INTO TABLE t`,
+			expect:      []string{`{"Quote": "\""}`},
+			removed:     "LOAD 'Reader:csv:1'  DATA INTO TABLE t",
+		},
+		{
+			description: "unterminated hint",
+			SQL:         "SELECT /*+ a=1",
+			removed:     "SELECT /*+ a=1",
+		},
+	}
+	for _, testCase := range testCases {
+		var actual []string
+		for _, block := range Find(testCase.SQL) {
+			actual = append(actual, block.Text)
+		}
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+		assert.Equal(t, testCase.removed, Remove(testCase.SQL), testCase.description)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	type embedded struct {
+		MaximumBytesBilled int64 `json:"maximumBytesBilled,omitempty,string"`
+		UseQueryCache      *bool
+	}
+	type target struct {
+		embedded
+		Labels    map[string]string
+		ClusterBy []string
+		Timeout   time.Duration
+		Name      string
+	}
+	cache := false
+	var testCases = []struct {
+		description string
+		hint        string
+		known       bool
+		expect      target
+		hasError    bool
+	}{
+		{
+			description: "compact hint",
+			hint:        `Labels=team:ads,env:prod clusterBy=a,b maximumBytesBilled=10 UseQueryCache=false Timeout=2s name="a b"`,
+			expect: target{
+				embedded:  embedded{MaximumBytesBilled: 10, UseQueryCache: &cache},
+				Labels:    map[string]string{"team": "ads", "env": "prod"},
+				ClusterBy: []string{"a", "b"},
+				Timeout:   2 * time.Second,
+				Name:      "a b",
+			},
+		},
+		{
+			description: "single quoted value",
+			hint:        `Name='it\'s'`,
+			expect:      target{Name: "it's"},
+		},
+		{
+			description: "JSON hint",
+			hint:        `{"Name": "x", "maximumBytesBilled": "5"}`,
+			expect:      target{Name: "x", embedded: embedded{MaximumBytesBilled: 5}},
+		},
+		{
+			description: "unknown compact key",
+			hint:        "Nme=x",
+			hasError:    true,
+		},
+		{
+			description: "unknown JSON key",
+			hint:        `{"Nme": "x"}`,
+			hasError:    true,
+		},
+		{
+			description: "unknown keys ignored",
+			hint:        "Nme=x Name=y",
+			known:       true,
+			expect:      target{Name: "y"},
+		},
+		{
+			description: "missing value separator",
+			hint:        "Name",
+			hasError:    true,
+		},
+		{
+			description: "invalid value",
+			hint:        "Timeout=soon",
+			hasError:    true,
+		},
+	}
+	for _, testCase := range testCases {
+		actual := target{}
+		var err error
+		if testCase.known {
+			err = DecodeKnown(testCase.hint, &actual)
+		} else {
+			err = Decode(testCase.hint, &actual)
+		}
+		if testCase.hasError {
+			assert.Error(t, err, testCase.description)
+			continue
+		}
+		if !assert.NoError(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
+
+func TestValidateKeys(t *testing.T) {
+	type load struct {
+		SourceFormat string `json:"sourceFormat,omitempty"`
+	}
+	type export struct {
+		StagingURI string
+	}
+	var testCases = []struct {
+		description string
+		hint        string
+		hasError    bool
+	}{
+		{description: "compact keys split across targets", hint: "sourceformat=CSV StagingURI=gs://b/p"},
+		{description: "JSON keys split across targets", hint: `{"SourceFormat":"CSV","stagingURI":"gs://b/p"}`},
+		{description: "unknown compact key", hint: "SourceFormt=CSV", hasError: true},
+		{description: "unknown JSON key", hint: `{"StagingUri":"gs://b/p","Format":"CSV"}`, hasError: true},
+		{description: "malformed hint", hint: "SourceFormat", hasError: true},
+	}
+	for _, testCase := range testCases {
+		err := ValidateKeys(testCase.hint, &load{}, &export{})
+		assert.Equal(t, testCase.hasError, err != nil, testCase.description)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	for _, value := range []string{"abc", "a b", `say "x"`, "", "a+*/b"} {
+		pairs, err := Parse("Key=" + Quote(value))
+		if assert.NoError(t, err, value) && assert.Len(t, pairs, 1, value) {
+			assert.Equal(t, value, pairs[0].Value, value)
+		}
+	}
+}
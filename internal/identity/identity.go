@@ -0,0 +1,53 @@
+package identity
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+
+	"golang.org/x/oauth2"
+)
+
+type contextKey struct{}
+
+// Identity represents per context caller credentials, key identifies cached HTTP client of the identity,
+// Err reports invalid credentials when the identity is used
+type Identity struct {
+	Key             interface{}
+	TokenSource     oauth2.TokenSource
+	CredentialsJSON []byte
+	Err             error
+}
+
+// WithTokenSource returns identity of the token source, the token source is the cache key, thus it has to be comparable (i.e. a pointer)
+func WithTokenSource(source oauth2.TokenSource) *Identity {
+	if source == nil {
+		return &Identity{Err: fmt.Errorf("token source was nil")}
+	}
+	if !reflect.TypeOf(source).Comparable() {
+		return &Identity{Err: fmt.Errorf("unsupported token source type: %T, expected comparable type (i.e. a pointer)", source)}
+	}
+	return &Identity{Key: source, TokenSource: source}
+}
+
+// WithCredentialsJSON returns identity of the credentials JSON, the key is the JSON digest
+func WithCredentialsJSON(data []byte) *Identity {
+	digest := sha256.Sum256(data)
+	return &Identity{Key: "json:" + hex.EncodeToString(digest[:]), CredentialsJSON: data}
+}
+
+// NewContext returns a context carrying identity
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, identity)
+}
+
+// FromContext returns identity carried by ctx or nil
+func FromContext(ctx context.Context) *Identity {
+	if ctx == nil {
+		return nil
+	}
+	result, _ := ctx.Value(contextKey{}).(*Identity)
+	return result
+}
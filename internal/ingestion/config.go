@@ -1,19 +1,80 @@
 package ingestion
 
 import (
-	"encoding/json"
 	"fmt"
+	"github.com/viant/bigquery/internal/hint"
+	"github.com/viant/bigquery/reader"
 	"google.golang.org/api/bigquery/v2"
 	"strings"
 )
 
 type configLoad bigquery.JobConfigurationLoad
 
+// jobOptions represents job level hint options
+type jobOptions struct {
+	Labels              map[string]string
+	Schema              *bigquery.TableSchema
+	CreateDisposition   string
+	SchemaUpdateOptions []string
+}
+
+// applyHintOptions updates ingestion with job options extracted from a hint, SQL options take precedence,
+// hint keys are decoded by several targets, thus keys unknown to all of them are reported upfront
+func applyHintOptions(aHint string, ingestion *ingestion) error {
+	if err := hint.ValidateKeys(aHint, &jobOptions{}, &configLoad{}, &configExtract{}, &exportOptions{}); err != nil {
+		return fmt.Errorf("invalid hint %v, %w", aHint, err)
+	}
+	options := jobOptions{}
+	if err := hint.DecodeKnown(aHint, &options); err != nil {
+		return fmt.Errorf("invalid hint %v, %w", aHint, err)
+	}
+	ingestion.Labels = options.Labels
+	ingestion.Schema = options.Schema
+	if ingestion.CreateDisposition == "" {
+		ingestion.CreateDisposition = strings.ToUpper(options.CreateDisposition)
+	}
+	for _, option := range options.SchemaUpdateOptions {
+		ingestion.addSchemaUpdateOption(strings.ToUpper(option))
+	}
+	return validateTableOptions(ingestion)
+}
+
+// validateTableOptions validates destination table options
+func validateTableOptions(ingestion *ingestion) error {
+	switch ingestion.CreateDisposition {
+	case "", createIfNeeded, createNever:
+	default:
+		return fmt.Errorf("unsupported CreateDisposition: %v, supported: [%v|%v]", ingestion.CreateDisposition, createIfNeeded, createNever)
+	}
+	for _, option := range ingestion.SchemaUpdateOptions {
+		switch option {
+		case allowFieldAddition, allowFieldRelaxation:
+		default:
+			return fmt.Errorf("unsupported SchemaUpdateOptions: %v, supported: [%v|%v]", option, allowFieldAddition, allowFieldRelaxation)
+		}
+	}
+	return nil
+}
+
 func (s *Service) prepareLoadConfig(ingestion *ingestion) (*bigquery.JobConfigurationLoad, error) {
 	config := configLoad{}
 	if aHint := ingestion.Hint; aHint != "" {
-		if err := json.Unmarshal([]byte(aHint), &config); err != nil {
-			return nil, err
+		if err := hint.DecodeKnown(aHint, &config); err != nil {
+			return nil, fmt.Errorf("invalid hint %v, %w", aHint, err)
+		}
+	}
+	if config.Schema == nil && ingestion.ReaderID != "" {
+		if tableSchema := reader.Schema(ingestion.ReaderID); tableSchema != nil {
+			config.Schema = tableSchema
+			config.Autodetect = false
+		}
+	}
+	if ingestion.CreateDisposition != "" {
+		config.CreateDisposition = ingestion.CreateDisposition
+	}
+	for _, option := range ingestion.SchemaUpdateOptions {
+		if !hasOption(config.SchemaUpdateOptions, option) {
+			config.SchemaUpdateOptions = append(config.SchemaUpdateOptions, option)
 		}
 	}
 	config.DestinationTable = &bigquery.TableReference{
@@ -29,6 +90,12 @@ func (s *Service) prepareLoadConfig(ingestion *ingestion) (*bigquery.JobConfigur
 		config.initJSON()
 	case "PARQUET":
 		config.initPARQUET()
+	case "AVRO":
+		config.initAVRO()
+	case "ORC":
+		config.initORC()
+	case "DATASTORE_BACKUP":
+		config.initDatastoreBackup()
 	default:
 		return nil, fmt.Errorf("unsupported load formt: %v", ingestion.Format)
 	}
@@ -53,3 +120,30 @@ func (c *configLoad) initPARQUET() {
 		c.SourceFormat = "PARQUET"
 	}
 }
+
+func (c *configLoad) initAVRO() {
+	if c.SourceFormat == "" {
+		c.SourceFormat = "AVRO"
+	}
+}
+
+func (c *configLoad) initORC() {
+	if c.SourceFormat == "" {
+		c.SourceFormat = "ORC"
+	}
+}
+
+func (c *configLoad) initDatastoreBackup() {
+	if c.SourceFormat == "" {
+		c.SourceFormat = "DATASTORE_BACKUP"
+	}
+}
+
+func hasOption(options []string, option string) bool {
+	for _, candidate := range options {
+		if candidate == option {
+			return true
+		}
+	}
+	return false
+}
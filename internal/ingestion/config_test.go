@@ -0,0 +1,65 @@
+package ingestion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_PrepareLoadConfig_TableOptions(t *testing.T) {
+	var testCases = []struct {
+		description               string
+		ingestion                 *ingestion
+		expectCreateDisposition   string
+		expectSchemaUpdateOptions []string
+		hasError                  bool
+	}{
+		{
+			description:             "hint options",
+			ingestion:               &ingestion{Destination: &destination{TableID: "t"}, Format: "csv", Hint: `{"CreateDisposition":"create_if_needed","SchemaUpdateOptions":["ALLOW_FIELD_ADDITION"]}`},
+			expectCreateDisposition: "CREATE_IF_NEEDED", expectSchemaUpdateOptions: []string{"ALLOW_FIELD_ADDITION"},
+		},
+		{
+			description:             "SQL and hint options",
+			ingestion:               &ingestion{Destination: &destination{TableID: "t"}, Format: "json", CreateDisposition: "CREATE_IF_NEEDED", SchemaUpdateOptions: []string{"ALLOW_FIELD_RELAXATION"}, Hint: `{"CreateDisposition":"CREATE_NEVER","SchemaUpdateOptions":["ALLOW_FIELD_ADDITION","ALLOW_FIELD_RELAXATION"]}`},
+			expectCreateDisposition: "CREATE_IF_NEEDED", expectSchemaUpdateOptions: []string{"ALLOW_FIELD_ADDITION", "ALLOW_FIELD_RELAXATION"},
+		},
+		{
+			description: "unsupported schema update option",
+			ingestion:   &ingestion{Destination: &destination{TableID: "t"}, Format: "csv", Hint: `{"SchemaUpdateOptions":["ALLOW_FIELD_DELETION"]}`},
+			hasError:    true,
+		},
+		{
+			description:             "load, extract and export keys",
+			ingestion:               &ingestion{Destination: &destination{TableID: "t"}, Format: "csv", Hint: `FieldDelimiter=";" PrintHeader=false StagingURI=gs://bucket/tmp CreateDisposition=CREATE_NEVER`},
+			expectCreateDisposition: "CREATE_NEVER",
+		},
+		{
+			description: "misspelled compact key",
+			ingestion:   &ingestion{Destination: &destination{TableID: "t"}, Format: "csv", Hint: "CreateDispositon=CREATE_NEVER"},
+			hasError:    true,
+		},
+		{
+			description: "misspelled JSON key",
+			ingestion:   &ingestion{Destination: &destination{TableID: "t"}, Format: "csv", Hint: `{"StagingURI":"gs://bucket/tmp","SkipLeadingRow":1}`},
+			hasError:    true,
+		},
+	}
+	srv := &Service{}
+	for _, testCase := range testCases {
+		err := applyHintOptions(testCase.ingestion.Hint, testCase.ingestion)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		actual, err := srv.prepareLoadConfig(testCase.ingestion)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expectCreateDisposition, actual.CreateDisposition, testCase.description)
+		assert.Equal(t, testCase.expectSchemaUpdateOptions, actual.SchemaUpdateOptions, testCase.description)
+	}
+}
@@ -0,0 +1,247 @@
+package ingestion
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/viant/bigquery/internal/exec"
+	"github.com/viant/bigquery/internal/hint"
+	"github.com/viant/bigquery/internal/label"
+	"github.com/viant/bigquery/internal/query"
+	"github.com/viant/bigquery/writer"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+	"google.golang.org/api/storage/v1"
+	"io"
+	"sort"
+	"strings"
+)
+
+const gsScheme = "gs://"
+
+type (
+	configExtract bigquery.JobConfigurationExtract
+
+	// exportOptions represents export hint options
+	exportOptions struct {
+		//StagingURI gs://<bucket>/<path> location used to stage data exported to a writer
+		StagingURI string
+	}
+)
+
+var (
+	exportFormats = map[string]string{
+		"CSV":                    "CSV",
+		"JSON":                   "NEWLINE_DELIMITED_JSON",
+		"NEWLINE_DELIMITED_JSON": "NEWLINE_DELIMITED_JSON",
+		"AVRO":                   "AVRO",
+		"PARQUET":                "PARQUET",
+	}
+	exportExtensions = map[string]string{
+		"CSV":                    ".csv",
+		"NEWLINE_DELIMITED_JSON": ".json",
+		"AVRO":                   ".avro",
+		"PARQUET":                ".parquet",
+	}
+	exportCompressions = map[string][]string{
+		"CSV":                    {"NONE", "GZIP"},
+		"NEWLINE_DELIMITED_JSON": {"NONE", "GZIP"},
+		"AVRO":                   {"NONE", "DEFLATE", "SNAPPY"},
+		"PARQUET":                {"NONE", "GZIP", "SNAPPY", "ZSTD"},
+	}
+)
+
+func (s *Service) prepareExtractConfig(ingestion *ingestion) (*bigquery.JobConfigurationExtract, error) {
+	config := configExtract{}
+	if aHint := ingestion.Hint; aHint != "" {
+		if err := hint.DecodeKnown(aHint, &config); err != nil {
+			return nil, fmt.Errorf("invalid hint %v, %w", aHint, err)
+		}
+	}
+	config.SourceTable = &bigquery.TableReference{
+		DatasetId: ingestion.Destination.DatasetID,
+		ProjectId: ingestion.Destination.ProjectID,
+		TableId:   ingestion.Destination.TableID,
+	}
+	if ingestion.Format != "" {
+		config.DestinationFormat = ingestion.Format
+	}
+	if config.DestinationFormat == "" {
+		config.DestinationFormat = formatFromURI(ingestion.TargetURI)
+	}
+	format, ok := exportFormats[strings.ToUpper(config.DestinationFormat)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported export format: %v", config.DestinationFormat)
+	}
+	config.DestinationFormat = format
+	if config.Compression != "" {
+		config.Compression = strings.ToUpper(config.Compression)
+		if !contains(exportCompressions[format], config.Compression) {
+			return nil, fmt.Errorf("unsupported %v export compression: %v, supported: %v", format, config.Compression, exportCompressions[format])
+		}
+	}
+	if ingestion.TargetURI != "" {
+		config.DestinationUris = []string{ingestion.TargetURI}
+	}
+	result := bigquery.JobConfigurationExtract(config)
+	return &result, nil
+}
+
+// export exports table data with an extract job, returned affected rows are always 0 as extract job
+// statistics carry no row count
+func (s *Service) export(ctx context.Context, ingestion *ingestion) (int64, error) {
+	aConfig, err := s.prepareExtractConfig(ingestion)
+	if err != nil {
+		return 0, err
+	}
+	var stagingURI string
+	var aWriter io.Writer
+	if ingestion.WriterID != "" {
+		defer writer.Unregister(ingestion.WriterID)
+		if aWriter, err = writer.Get(ingestion.WriterID); err != nil {
+			return 0, err
+		}
+		options := exportOptions{}
+		if err = hint.DecodeKnown(ingestion.Hint, &options); err != nil {
+			return 0, fmt.Errorf("invalid hint %v, %w", ingestion.Hint, err)
+		}
+		if !strings.HasPrefix(options.StagingURI, gsScheme) {
+			return 0, fmt.Errorf("export to writer requires StagingURI gs://<bucket>/<path> hint option")
+		}
+		stagingURI = strings.TrimRight(options.StagingURI, "/") + "/" + uuid.New().String() + "/"
+		aConfig.DestinationUris = []string{stagingURI + stagedObject(aConfig)}
+		//staged objects are removed even if the job failed, as it may have written some files
+		defer s.removeStaged(context.WithoutCancel(ctx), stagingURI)
+	}
+	job := &bigquery.Job{Configuration: &bigquery.JobConfiguration{Extract: aConfig}}
+	job.Configuration.Labels = label.Merge(s.labels, ingestion.Labels, label.FromContext(ctx))
+	if err = label.Validate(job.Configuration.Labels); err != nil {
+		return 0, err
+	}
+	if job, err = s.submitJob(ctx, job, nil); err != nil {
+		return 0, err
+	}
+	if _, err = exec.WaitForJobCompletion(ctx, s.service, s.projectID, s.location, job.JobReference.JobId); err != nil {
+		return 0, err
+	}
+	if aWriter != nil {
+		skipHeader := aConfig.DestinationFormat == "CSV" && (aConfig.PrintHeader == nil || *aConfig.PrintHeader)
+		return 0, s.transferStaged(ctx, stagingURI, aWriter, skipHeader)
+	}
+	return 0, nil
+}
+
+// stagedObject returns staged object name, only uncompressed CSV and JSON files can be concatenated,
+// thus other formats are staged as a single object (limited by BigQuery to 1 GB)
+func stagedObject(config *bigquery.JobConfigurationExtract) string {
+	extension := exportExtensions[config.DestinationFormat]
+	switch config.DestinationFormat {
+	case "NEWLINE_DELIMITED_JSON":
+		return "part-*" + extension
+	case "CSV":
+		if config.Compression == "" || config.Compression == "NONE" {
+			return "part-*" + extension
+		}
+	}
+	return "data" + extension
+}
+
+func (s *Service) storageService(ctx context.Context) (*storage.Service, error) {
+	return storage.NewService(ctx, option.WithHTTPClient(query.HTTPClient(s.service)))
+}
+
+// stagedObjects returns sorted names of objects staged under the URI
+func (s *Service) stagedObjects(ctx context.Context, storageService *storage.Service, stagingURI string) (string, []string, error) {
+	bucket, prefix := splitGsURI(stagingURI)
+	var names []string
+	err := storageService.Objects.List(bucket).Prefix(prefix).Pages(ctx, func(objects *storage.Objects) error {
+		for _, item := range objects.Items {
+			names = append(names, item.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list staged objects: %v, %w", stagingURI, err)
+	}
+	sort.Strings(names)
+	return bucket, names, nil
+}
+
+// transferStaged copies staged objects to the writer, skipHeader drops CSV header of all but the first object
+func (s *Service) transferStaged(ctx context.Context, stagingURI string, aWriter io.Writer, skipHeader bool) error {
+	storageService, err := s.storageService(ctx)
+	if err != nil {
+		return err
+	}
+	bucket, names, err := s.stagedObjects(ctx, storageService, stagingURI)
+	if err != nil {
+		return err
+	}
+	for i, name := range names {
+		if err = s.transferObject(ctx, storageService, bucket, name, aWriter, skipHeader && i > 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeStaged removes staged objects
+func (s *Service) removeStaged(ctx context.Context, stagingURI string) {
+	storageService, err := s.storageService(ctx)
+	if err != nil {
+		return
+	}
+	bucket, names, err := s.stagedObjects(ctx, storageService, stagingURI)
+	if err != nil {
+		return
+	}
+	for _, name := range names {
+		_ = storageService.Objects.Delete(bucket, name).Context(ctx).Do()
+	}
+}
+
+func (s *Service) transferObject(ctx context.Context, storageService *storage.Service, bucket, name string, aWriter io.Writer, skipHeader bool) error {
+	response, err := storageService.Objects.Get(bucket, name).Context(ctx).Download()
+	if err != nil {
+		return fmt.Errorf("failed to download staged object: %v%v/%v, %w", gsScheme, bucket, name, err)
+	}
+	defer response.Body.Close()
+	var source io.Reader = response.Body
+	if skipHeader {
+		buffered := bufio.NewReader(response.Body)
+		if _, err = buffered.ReadString('\n'); err != nil && err != io.EOF {
+			return fmt.Errorf("failed to read staged object: %v%v/%v, %w", gsScheme, bucket, name, err)
+		}
+		source = buffered
+	}
+	_, err = io.Copy(aWriter, source)
+	return err
+}
+
+func splitGsURI(URI string) (string, string) {
+	location := strings.TrimPrefix(URI, gsScheme)
+	if index := strings.Index(location, "/"); index != -1 {
+		return location[:index], location[index+1:]
+	}
+	return location, ""
+}
+
+func formatFromURI(URI string) string {
+	URI = strings.ToLower(strings.TrimSuffix(URI, ".gz"))
+	for format, ext := range exportExtensions {
+		if strings.HasSuffix(URI, ext) {
+			return format
+		}
+	}
+	return "CSV"
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package ingestion
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+)
+
+func TestService_PrepareExtractConfig(t *testing.T) {
+	var testCases = []struct {
+		description       string
+		ingestion         *ingestion
+		expectFormat      string
+		expectCompression string
+		expectURIs        []string
+		hasError          bool
+	}{
+		{
+			description:  "format from URI",
+			ingestion:    &ingestion{Destination: &destination{TableID: "t"}, TargetURI: "gs://bucket/t-*.json.gz", Hint: `{"Compression":"gzip"}`},
+			expectFormat: "NEWLINE_DELIMITED_JSON", expectCompression: "GZIP",
+			expectURIs: []string{"gs://bucket/t-*.json.gz"},
+		},
+		{
+			description:  "writer format",
+			ingestion:    &ingestion{Destination: &destination{TableID: "t"}, Format: "parquet", WriterID: "1", Hint: `{"Compression":"SNAPPY"}`},
+			expectFormat: "PARQUET", expectCompression: "SNAPPY",
+		},
+		{
+			description: "unsupported compression",
+			ingestion:   &ingestion{Destination: &destination{TableID: "t"}, Format: "csv", WriterID: "1", Hint: `{"Compression":"SNAPPY"}`},
+			hasError:    true,
+		},
+	}
+	srv := &Service{}
+	for _, testCase := range testCases {
+		actual, err := srv.prepareExtractConfig(testCase.ingestion)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expectFormat, actual.DestinationFormat, testCase.description)
+		assert.Equal(t, testCase.expectCompression, actual.Compression, testCase.description)
+		assert.Equal(t, testCase.expectURIs, actual.DestinationUris, testCase.description)
+	}
+}
+
+func TestStagedObject(t *testing.T) {
+	var testCases = []struct {
+		description string
+		config      *bigquery.JobConfigurationExtract
+		expect      string
+	}{
+		{description: "csv shards", config: &bigquery.JobConfigurationExtract{DestinationFormat: "CSV"}, expect: "part-*.csv"},
+		{description: "compressed csv", config: &bigquery.JobConfigurationExtract{DestinationFormat: "CSV", Compression: "GZIP"}, expect: "data.csv"},
+		{description: "json shards", config: &bigquery.JobConfigurationExtract{DestinationFormat: "NEWLINE_DELIMITED_JSON", Compression: "GZIP"}, expect: "part-*.json"},
+		{description: "parquet", config: &bigquery.JobConfigurationExtract{DestinationFormat: "PARQUET"}, expect: "data.parquet"},
+		{description: "avro", config: &bigquery.JobConfigurationExtract{DestinationFormat: "AVRO"}, expect: "data.avro"},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, stagedObject(testCase.config), testCase.description)
+	}
+}
+
+// redirectTransport sends all requests to the test server
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (r *redirectTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+	request.URL.Scheme = r.target.Scheme
+	request.URL.Host = r.target.Host
+	return http.DefaultTransport.RoundTrip(request)
+}
+
+func TestService_TransferStaged(t *testing.T) {
+	objects := map[string]string{
+		"staging/x/part-000000000000.csv": "id,name\n1,a\n",
+		"staging/x/part-000000000001.csv": "id,name\n2,b\n",
+	}
+	var mux sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.Lock()
+		defer mux.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		name, _ := url.PathUnescape(r.URL.EscapedPath()[strings.LastIndex(r.URL.EscapedPath(), "/")+1:])
+		switch {
+		case r.Method == http.MethodDelete:
+			delete(objects, name)
+		case r.URL.Query().Get("alt") == "media":
+			_, _ = w.Write([]byte(objects[name]))
+		default:
+			var items []string
+			for key := range objects {
+				items = append(items, fmt.Sprintf(`{"name": %q}`, key))
+			}
+			_, _ = fmt.Fprintf(w, `{"items": [%v]}`, strings.Join(items, ","))
+		}
+	}))
+	defer server.Close()
+	target, _ := url.Parse(server.URL)
+	service, err := bigquery.NewService(context.Background(), option.WithHTTPClient(&http.Client{Transport: &redirectTransport{target: target}}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	srv := &Service{service: service}
+	buffer := new(bytes.Buffer)
+	if assert.NoError(t, srv.transferStaged(context.Background(), "gs://bucket/staging/x/", buffer, true)) {
+		assert.Equal(t, "id,name\n1,a\n2,b\n", buffer.String())
+	}
+	srv.removeStaged(context.Background(), "gs://bucket/staging/x/")
+	assert.Empty(t, objects)
+}
@@ -23,7 +23,7 @@ outer:
 	}
 	value := strings.TrimSpace(string(data[offset+len(match) : limit]))
 	if len(value) > 0 && value[0] == '"' {
-		value = value[1 : len(value)-2]
+		value = value[1 : len(value)-1]
 	}
 	return value, nil
 }
@@ -0,0 +1,33 @@
+package ingestion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractJSONKeyValue(t *testing.T) {
+	var testCases = []struct {
+		description string
+		data        string
+		key         string
+		expect      string
+		hasError    bool
+	}{
+		{description: "quoted value", data: `{"id":"abc","name":"x"}`, key: "id", expect: "abc"},
+		{description: "last quoted value", data: `{"name":"x","id":"abc"}`, key: "id", expect: "abc"},
+		{description: "single char value", data: `{"id":"a"}`, key: "id", expect: "a"},
+		{description: "numeric value", data: `{"id": 123 ,"name":"x"}`, key: "id", expect: "123"},
+		{description: "missing key", data: `{"name":"x"}`, key: "id", hasError: true},
+	}
+	for _, testCase := range testCases {
+		actual, err := extractJSONKeyValue([]byte(testCase.data), testCase.key)
+		if testCase.hasError {
+			assert.Error(t, err, testCase.description)
+			continue
+		}
+		if assert.NoError(t, err, testCase.description) {
+			assert.Equal(t, testCase.expect, actual, testCase.description)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package ingestion
 
+import "google.golang.org/api/bigquery/v2"
+
 type kind string
 
 const (
@@ -8,6 +10,16 @@ const (
 
 	// KindStream means supported stream data ability
 	KindStream = kind("STREAM")
+
+	// KindExport means supported export data ability
+	KindExport = kind("EXPORT")
+)
+
+const (
+	createIfNeeded       = "CREATE_IF_NEEDED"
+	createNever          = "CREATE_NEVER"
+	allowFieldAddition   = "ALLOW_FIELD_ADDITION"
+	allowFieldRelaxation = "ALLOW_FIELD_RELAXATION"
 )
 
 type (
@@ -21,9 +33,18 @@ type (
 		Destination   *destination
 		Kind          kind
 		Format        string
+		Codec         string
 		InsertIDField string
 		ReaderID      string
+		WriterID      string
+		TargetURI     string
 		Hint          string
+		Labels        map[string]string
+		Schema        *bigquery.TableSchema
+		//CreateDisposition CREATE_IF_NEEDED creates missing destination table
+		CreateDisposition string
+		//SchemaUpdateOptions ALLOW_FIELD_ADDITION and ALLOW_FIELD_RELAXATION apply additive destination schema changes
+		SchemaUpdateOptions []string
 	}
 )
 
@@ -35,3 +56,10 @@ func (d *destination) init(projectID string, datasetID string) {
 		d.DatasetID = datasetID
 	}
 }
+
+// addSchemaUpdateOption adds schema update option unless already present
+func (i *ingestion) addSchemaUpdateOption(option string) {
+	if !hasOption(i.SchemaUpdateOptions, option) {
+		i.SchemaUpdateOptions = append(i.SchemaUpdateOptions, option)
+	}
+}
@@ -16,19 +16,37 @@ const (
 	dataIntoSequence
 	aDestination
 	dataFormat
+	tableKeyword
+	toKeyword
+	exportTarget
+	writerKeyword
+	exportFormat
+	withKeyword
+	tableOption
+	comma
 )
 
 var whitespaceMatcher = parsly.NewToken(whitespace, "WHITESPACE", matcher.NewWhiteSpace())
 
-var ingestionKindMatcher = parsly.NewToken(ingestionKindKeyword, "<LOAD|STREAM>", matcher.NewSet([]string{"LOAD", "STREAM"}, &option.Case{Sensitive: false}))
+var ingestionKindMatcher = parsly.NewToken(ingestionKindKeyword, "<LOAD|STREAM|EXPORT>", matcher.NewSet([]string{"LOAD", "STREAM", "EXPORT"}, &option.Case{Sensitive: false}))
 
-var readOptionsMatcher = parsly.NewToken(readerOptions, "'Reader:<Format>:<ReaderID>'", matcher.NewByteQuote('\'', '\\'))
+var readOptionsMatcher = parsly.NewToken(readerOptions, "'Reader:<Format>[/<Codec>]:<ReaderID>'", matcher.NewByteQuote('\'', '\\'))
 var readerKeywordMatcher = parsly.NewToken(readerKeyword, "Reader", matcher.NewFragment("READER", &option.Case{Sensitive: false}))
 
-var dataFormatMatcher = parsly.NewToken(dataFormat, "<CSV|JSON|PARQUET>", matcher.NewSet([]string{"CSV", "JSON", "PARQUET"}, &option.Case{Sensitive: false}))
+var dataFormatMatcher = parsly.NewToken(dataFormat, "<CSV|JSON|PARQUET|AVRO|ORC|DATASTORE_BACKUP>[/<GZIP|ZSTD|NONE>]", matcher.NewSet([]string{"CSV", "JSON", "PARQUET", "AVRO", "ORC", "DATASTORE_BACKUP"}, &option.Case{Sensitive: false}))
 
 // TODO problem with parsing quota mark inside block e.g.: /*"*/
 //var hintMatcher = parsly.NewToken(hintOptions, "/*+ HINT +*/", matcher.NewSeqBlock("/*+", "+*/"))
 
 var dataIntoSequenceMatcher = parsly.NewToken(dataIntoSequence, "DATA INTO TABLE", matcher.NewSpacedFragment("DATA INTO TABLE", &option.Case{Sensitive: false}))
 var destinationMatcher = parsly.NewToken(aDestination, "project.set.table", smatcher.NewSelector())
+
+var tableKeywordMatcher = parsly.NewToken(tableKeyword, "TABLE", matcher.NewFragment("TABLE", &option.Case{Sensitive: false}))
+var toKeywordMatcher = parsly.NewToken(toKeyword, "TO", matcher.NewFragment("TO", &option.Case{Sensitive: false}))
+var exportTargetMatcher = parsly.NewToken(exportTarget, "'Writer:<Format>:<WriterID>'|'gs://<bucket>/<path>'", matcher.NewByteQuote('\'', '\\'))
+var writerKeywordMatcher = parsly.NewToken(writerKeyword, "Writer", matcher.NewFragment("WRITER", &option.Case{Sensitive: false}))
+var exportFormatMatcher = parsly.NewToken(exportFormat, "<CSV|JSON|NEWLINE_DELIMITED_JSON|AVRO|PARQUET>", matcher.NewSet([]string{"CSV", "JSON", "NEWLINE_DELIMITED_JSON", "AVRO", "PARQUET"}, &option.Case{Sensitive: false}))
+
+var withKeywordMatcher = parsly.NewToken(withKeyword, "WITH", matcher.NewFragment("WITH", &option.Case{Sensitive: false}))
+var tableOptionMatcher = parsly.NewToken(tableOption, "<CREATE_IF_NEEDED|ALLOW_FIELD_ADDITION|ALLOW_FIELD_RELAXATION>", matcher.NewSet([]string{createIfNeeded, allowFieldAddition, allowFieldRelaxation}, &option.Case{Sensitive: false}))
+var commaMatcher = parsly.NewToken(comma, ",", matcher.NewByte(','))
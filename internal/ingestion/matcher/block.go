@@ -0,0 +1,33 @@
+package matcher
+
+import (
+	"bytes"
+	"github.com/viant/parsly"
+)
+
+// Block represents delimited block matcher i.e. /* comment */, unlike parsly SeqBlock quotes inside the block are not tracked
+type Block struct {
+	begin        []byte
+	end          []byte
+	unterminated bool
+}
+
+// Match matches a block
+func (n *Block) Match(cursor *parsly.Cursor) (matched int) {
+	input := cursor.Input[cursor.Pos:]
+	if !bytes.HasPrefix(input, n.begin) {
+		return 0
+	}
+	if index := bytes.Index(input[len(n.begin):], n.end); index != -1 {
+		return len(n.begin) + index + len(n.end)
+	}
+	if n.unterminated {
+		return len(input)
+	}
+	return 0
+}
+
+// NewBlock creates a Block matcher, unterminated block matches the rest of input when unterminated is set
+func NewBlock(begin, end string, unterminated bool) *Block {
+	return &Block{begin: []byte(begin), end: []byte(end), unterminated: unterminated}
+}
@@ -0,0 +1,74 @@
+package matcher
+
+import (
+	"bytes"
+	"github.com/viant/parsly"
+)
+
+// StringLiteral represents single, double or triple quoted string literal matcher, unterminated literal matches the rest of input
+type StringLiteral struct{}
+
+// Match matches a string literal
+func (n *StringLiteral) Match(cursor *parsly.Cursor) (matched int) {
+	input := cursor.Input[cursor.Pos:]
+	quote := input[0]
+	if quote != '\'' && quote != '"' {
+		return 0
+	}
+	if triple := []byte{quote, quote, quote}; bytes.HasPrefix(input, triple) {
+		if index := bytes.Index(input[len(triple):], triple); index != -1 {
+			return index + 2*len(triple)
+		}
+		return len(input)
+	}
+	for i := 1; i < len(input); i++ {
+		switch input[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(input)
+}
+
+// NewStringLiteral creates a StringLiteral matcher
+func NewStringLiteral() *StringLiteral {
+	return &StringLiteral{}
+}
+
+// Number represents numeric literal matcher including decimal, exponent and hex forms, digits following identifier characters are not matched
+type Number struct{}
+
+// Match matches a numeric literal
+func (n *Number) Match(cursor *parsly.Cursor) (matched int) {
+	input := cursor.Input
+	pos := cursor.Pos
+	if !IsDigit(input[pos]) || (pos > 0 && IsIdentifier(input[pos-1])) {
+		return 0
+	}
+	for i := pos; i < len(input); i++ {
+		switch c := input[i]; {
+		case IsIdentifier(c), c == '.':
+		case (c == '+' || c == '-') && (input[i-1] == 'e' || input[i-1] == 'E'):
+		default:
+			return i - pos
+		}
+	}
+	return len(input) - pos
+}
+
+// NewNumber creates a Number matcher
+func NewNumber() *Number {
+	return &Number{}
+}
+
+// IsDigit checks if passed byte is a digit
+func IsDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// IsIdentifier checks if passed byte is an identifier character
+func IsIdentifier(b byte) bool {
+	return b == '_' || IsDigit(b) || IsLetter(b)
+}
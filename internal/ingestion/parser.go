@@ -2,7 +2,9 @@ package ingestion
 
 import (
 	"fmt"
+	"github.com/viant/bigquery/reader"
 	"github.com/viant/parsly"
+	"google.golang.org/api/bigquery/v2"
 	"strings"
 )
 
@@ -22,6 +24,10 @@ func parse(SQL string) (*ingestion, error) {
 		return nil, fmt.Errorf("%w, current token:%s", cursor.NewError(whitespaceMatcher), SQL)
 	}
 
+	if strings.EqualFold(string(result.Kind), string(KindExport)) {
+		return parseExport(cursor, SQL, result)
+	}
+
 	match = cursor.MatchAfterOptional(whitespaceMatcher, readOptionsMatcher)
 	if match.Code != readerOptions {
 		return nil, fmt.Errorf("%w, current token:%s", cursor.NewError(readOptionsMatcher), SQL)
@@ -68,18 +74,123 @@ func parse(SQL string) (*ingestion, error) {
 		return nil, err
 	}
 
+	if match = cursor.MatchAfterOptional(whitespaceMatcher, withKeywordMatcher); match.Code == withKeyword {
+		if err = parseTableOptions(cursor, SQL, result); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = matchEnd(cursor); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// parseTableOptions parses WITH <option>[, <option>] destination table options
+func parseTableOptions(cursor *parsly.Cursor, SQL string, result *ingestion) error {
+	for {
+		match := cursor.MatchAfterOptional(whitespaceMatcher, tableOptionMatcher)
+		if match.Code != tableOption {
+			return fmt.Errorf("%w, current token:%s", cursor.NewError(tableOptionMatcher), SQL)
+		}
+		switch tableOption := strings.ToUpper(match.Text(cursor)); tableOption {
+		case createIfNeeded:
+			result.CreateDisposition = createIfNeeded
+		default:
+			result.addSchemaUpdateOption(tableOption)
+		}
+		if match = cursor.MatchAfterOptional(whitespaceMatcher, commaMatcher); match.Code != comma {
+			return nil
+		}
+	}
+}
+
+// parseExport parses EXPORT TABLE <table> TO '<target>' statement
+func parseExport(cursor *parsly.Cursor, SQL string, result *ingestion) (*ingestion, error) {
+	match := cursor.MatchOne(tableKeywordMatcher)
+	if match.Code != tableKeyword {
+		return nil, fmt.Errorf("%w, current token:%s", cursor.NewError(tableKeywordMatcher), SQL)
+	}
 	match = cursor.MatchOne(whitespaceMatcher)
+	if match.Code != whitespace {
+		return nil, fmt.Errorf("%w, current token:%s", cursor.NewError(whitespaceMatcher), SQL)
+	}
+	match = cursor.MatchAfterOptional(whitespaceMatcher, destinationMatcher)
+	if match.Code != aDestination {
+		return nil, fmt.Errorf("%w, current token:%s", cursor.NewError(destinationMatcher), SQL)
+	}
+	if err := decodeDestination(match.Text(cursor), result); err != nil {
+		return nil, err
+	}
+	match = cursor.MatchOne(whitespaceMatcher)
+	if match.Code != whitespace {
+		return nil, fmt.Errorf("%w, current token:%s", cursor.NewError(whitespaceMatcher), SQL)
+	}
+	match = cursor.MatchAfterOptional(whitespaceMatcher, toKeywordMatcher)
+	if match.Code != toKeyword {
+		return nil, fmt.Errorf("%w, current token:%s", cursor.NewError(toKeywordMatcher), SQL)
+	}
+	match = cursor.MatchOne(whitespaceMatcher)
+	if match.Code != whitespace {
+		return nil, fmt.Errorf("%w, current token:%s", cursor.NewError(whitespaceMatcher), SQL)
+	}
+	match = cursor.MatchAfterOptional(whitespaceMatcher, exportTargetMatcher)
+	if match.Code != exportTarget {
+		return nil, fmt.Errorf("%w, current token:%s", cursor.NewError(exportTargetMatcher), SQL)
+	}
+	encodedTarget := match.Text(cursor)
+	if err := decodeExportTarget(encodedTarget[1:len(encodedTarget)-1], result); err != nil {
+		return nil, err
+	}
+	if err := matchEnd(cursor); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// decodeExportTarget updates ingestion with decoded Writer:<FORMAT>:<WriterID> or gs://<bucket>/<path> target
+func decodeExportTarget(text string, ingestion *ingestion) error {
+	if strings.HasPrefix(text, gsScheme) {
+		if len(text) == len(gsScheme) {
+			return fmt.Errorf("invalid export target: %s", text)
+		}
+		ingestion.TargetURI = text
+		return nil
+	}
+	opts := strings.SplitN(text, ":", 3)
+	if len(opts) != 3 {
+		return fmt.Errorf("failed to split export target:%s, supported:[%s]", text, exportTargetMatcher.Name)
+	}
+	cursor := parsly.NewCursor("", []byte(opts[0]), 0)
+	match := cursor.MatchOne(writerKeywordMatcher)
+	if match.Code != writerKeyword || cursor.HasMore() {
+		return fmt.Errorf("%w, current token:%s", cursor.NewError(writerKeywordMatcher), opts[0])
+	}
+	cursor = parsly.NewCursor("", []byte(opts[1]), 0)
+	match = cursor.MatchOne(exportFormatMatcher)
+	if match.Code != exportFormat || cursor.HasMore() {
+		return fmt.Errorf("%w, current token:%s", cursor.NewError(exportFormatMatcher), opts[1])
+	}
+	ingestion.Format = opts[1]
+	ingestion.WriterID = opts[2]
+	if ingestion.WriterID == "" {
+		return fmt.Errorf("writer ID was empty: %s", text)
+	}
+	return nil
+}
+
+func matchEnd(cursor *parsly.Cursor) error {
+	match := cursor.MatchOne(whitespaceMatcher)
 	switch match.Code {
 	case whitespace:
 		if cursor.HasMore() {
-			return nil, fmt.Errorf("unexpected sequence: %s", cursor.Input[cursor.Pos:])
+			return fmt.Errorf("unexpected sequence: %s", cursor.Input[cursor.Pos:])
 		}
 	case parsly.EOF:
 	default:
-		return nil, fmt.Errorf("unexpected sequence: %s", cursor.Input[cursor.Pos:])
+		return fmt.Errorf("unexpected sequence: %s", cursor.Input[cursor.Pos:])
 	}
-
-	return result, nil
+	return nil
 }
 
 func decodeReaderOptionsForLoad(text string, ingestion *ingestion) error {
@@ -95,12 +206,9 @@ func decodeReaderOptionsForLoad(text string, ingestion *ingestion) error {
 		return fmt.Errorf("%w, current token:%s", cursor.NewError(readerKeywordMatcher), opts[0])
 	}
 
-	cursor = parsly.NewCursor("", []byte(opts[1]), 0)
-	match = cursor.MatchOne(dataFormatMatcher)
-	if match.Code != dataFormat || cursor.HasMore() {
-		return fmt.Errorf("%w, current token:%s", cursor.NewError(dataFormatMatcher), opts[1])
+	if err := decodeDataFormat(opts[1], ingestion); err != nil {
+		return err
 	}
-	ingestion.Format = opts[1]
 	ingestion.ReaderID = opts[2]
 
 	return nil
@@ -121,17 +229,33 @@ func decodeReaderOptionsForStream(text string, ingestion *ingestion) error {
 
 	ingestion.InsertIDField = opts[1]
 
-	cursor = parsly.NewCursor("", []byte(opts[2]), 0)
-	match = cursor.MatchOne(dataFormatMatcher)
-	if match.Code != dataFormat || cursor.HasMore() {
-		return fmt.Errorf("%w, current token:%s", cursor.NewError(dataFormatMatcher), opts[1])
+	if err := decodeDataFormat(opts[2], ingestion); err != nil {
+		return err
 	}
-	ingestion.Format = opts[2]
 	ingestion.ReaderID = opts[3]
 
 	return nil
 }
 
+// decodeDataFormat updates ingestion with decoded <FORMAT>[/<CODEC>] values
+func decodeDataFormat(text string, ingestion *ingestion) error {
+	format := text
+	if index := strings.Index(text, "/"); index != -1 {
+		format = text[:index]
+		ingestion.Codec = strings.ToLower(text[index+1:])
+		if !reader.IsCodec(ingestion.Codec) {
+			return fmt.Errorf("unsupported codec: %v, supported: [%v|%v|%v]", ingestion.Codec, reader.CodecGzip, reader.CodecZstd, reader.CodecNone)
+		}
+	}
+	cursor := parsly.NewCursor("", []byte(format), 0)
+	match := cursor.MatchOne(dataFormatMatcher)
+	if match.Code != dataFormat || cursor.HasMore() {
+		return fmt.Errorf("%w, current token:%s", cursor.NewError(dataFormatMatcher), text)
+	}
+	ingestion.Format = format
+	return nil
+}
+
 // decodeDestination updates ingestion with decoded destination values
 func decodeDestination(text string, ingestion *ingestion) error {
 
@@ -176,3 +300,21 @@ func isValidSelector(id string) bool {
 	match := cursor.MatchOne(destinationMatcher)
 	return match.Code == aDestination
 }
+
+// ParseDestination parses [project.][dataset.]table destination, unspecified project and dataset use the supplied defaults
+func ParseDestination(text string, projectID, datasetID string) (*bigquery.TableReference, error) {
+	aIngestion := &ingestion{}
+	cursor := parsly.NewCursor("", []byte(text), 0)
+	if match := cursor.MatchOne(destinationMatcher); match.Code != aDestination || cursor.HasMore() {
+		return nil, fmt.Errorf("invalid destination: %v, supported:[%s]", text, destinationMatcher.Name)
+	}
+	if err := decodeDestination(text, aIngestion); err != nil {
+		return nil, err
+	}
+	dest := aIngestion.Destination
+	dest.init(projectID, datasetID)
+	if dest.DatasetID == "" {
+		return nil, fmt.Errorf("invalid destination: %v, dataset was empty", text)
+	}
+	return &bigquery.TableReference{ProjectId: dest.ProjectID, DatasetId: dest.DatasetID, TableId: dest.TableID}, nil
+}
@@ -548,6 +548,132 @@ func TestParse(t *testing.T) {
 				ReaderID: "123e4567-e89b-12d3-a456-426614174012",
 			},
 		},
+		{
+			description: "AVRO load",
+			SQL:         "LOAD 'Reader:avro:123' DATA INTO TABLE project.set.table",
+			expect: &ingestion{
+				Destination: &destination{ProjectID: "project", DatasetID: "set", TableID: "table"},
+				Kind:        "LOAD",
+				Format:      "avro",
+				ReaderID:    "123",
+			},
+		},
+		{
+			description: "ORC load",
+			SQL:         "LOAD 'Reader:ORC:123' DATA INTO TABLE set.table",
+			expect: &ingestion{
+				Destination: &destination{DatasetID: "set", TableID: "table"},
+				Kind:        "LOAD",
+				Format:      "ORC",
+				ReaderID:    "123",
+			},
+		},
+		{
+			description: "gzip CSV load",
+			SQL:         "LOAD 'Reader:csv/gzip:123' DATA INTO TABLE table",
+			expect: &ingestion{
+				Destination: &destination{TableID: "table"},
+				Kind:        "LOAD",
+				Format:      "csv",
+				Codec:       "gzip",
+				ReaderID:    "123",
+			},
+		},
+		{
+			description: "zstd JSON stream",
+			SQL:         "STREAM 'Reader:ID:json/ZSTD:123' DATA INTO TABLE table",
+			expect: &ingestion{
+				Destination:   &destination{TableID: "table"},
+				Kind:          "STREAM",
+				Format:        "json",
+				Codec:         "zstd",
+				InsertIDField: "ID",
+				ReaderID:      "123",
+			},
+		},
+		{
+			description: "unsupported codec",
+			SQL:         "LOAD 'Reader:csv/lz4:123' DATA INTO TABLE table",
+			hasError:    true,
+		},
+		{
+			description: "stream with table options",
+			SQL:         "STREAM 'Reader:ID:json:123' DATA INTO TABLE set.table WITH create_if_needed, ALLOW_FIELD_ADDITION ,ALLOW_FIELD_RELAXATION",
+			expect: &ingestion{
+				Destination: &destination{
+					DatasetID: "set",
+					TableID:   "table",
+				},
+				Kind:                "STREAM",
+				Format:              "json",
+				InsertIDField:       "ID",
+				ReaderID:            "123",
+				CreateDisposition:   "CREATE_IF_NEEDED",
+				SchemaUpdateOptions: []string{"ALLOW_FIELD_ADDITION", "ALLOW_FIELD_RELAXATION"},
+			},
+		},
+		{
+			description: "load with table option",
+			SQL:         "LOAD 'Reader:csv:123' DATA INTO TABLE table WITH ALLOW_FIELD_ADDITION",
+			expect: &ingestion{
+				Destination:         &destination{TableID: "table"},
+				Kind:                "LOAD",
+				Format:              "csv",
+				ReaderID:            "123",
+				SchemaUpdateOptions: []string{"ALLOW_FIELD_ADDITION"},
+			},
+		},
+		{
+			description: "load with unsupported table option",
+			SQL:         "LOAD 'Reader:csv:123' DATA INTO TABLE table WITH CREATE_NEVER",
+			hasError:    true,
+		},
+		{
+			description: "load with dangling table option separator",
+			SQL:         "LOAD 'Reader:csv:123' DATA INTO TABLE table WITH CREATE_IF_NEEDED,",
+			hasError:    true,
+		},
+		{
+			description: "export to writer",
+			SQL:         "EXPORT TABLE project.set.table TO 'Writer:parquet:123e4567-e89b-12d3-a456-426614174012'",
+			expect: &ingestion{
+				Destination: &destination{
+					ProjectID: "project",
+					DatasetID: "set",
+					TableID:   "table",
+				},
+				Kind:     "EXPORT",
+				Format:   "parquet",
+				WriterID: "123e4567-e89b-12d3-a456-426614174012",
+			},
+		},
+		{
+			description: "export to gs",
+			SQL:         "export table  set.table  to  'gs://bucket/folder/table-*.json' ",
+			expect: &ingestion{
+				Destination: &destination{
+					DatasetID: "set",
+					TableID:   "table",
+				},
+				Kind:      "export",
+				TargetURI: "gs://bucket/folder/table-*.json",
+			},
+		},
+		{
+			description: "export with unsupported format",
+			SQL:         "EXPORT TABLE project.set.table TO 'Writer:orc:123'",
+			hasError:    true,
+		},
+		{
+			description: "export without TABLE keyword",
+			SQL:         "EXPORT project.set.table TO 'gs://bucket/table.csv'",
+			hasError:    true,
+		},
+		{
+			description: "export without target",
+			SQL:         "EXPORT TABLE project.set.table TO ",
+			hasError:    true,
+		},
 	}
 
 	for _, testCase := range testCases {
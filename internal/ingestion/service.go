@@ -8,11 +8,16 @@ import (
 	"fmt"
 	"github.com/viant/bigquery/internal/exec"
 	"github.com/viant/bigquery/internal/hint"
+	"github.com/viant/bigquery/internal/label"
+	"github.com/viant/bigquery/internal/logging"
+	"github.com/viant/bigquery/internal/trace"
+	"github.com/viant/bigquery/metric"
 	"github.com/viant/bigquery/reader"
 	"google.golang.org/api/bigquery/v2"
 	"google.golang.org/api/googleapi"
 	"io"
 	"strings"
+	"time"
 )
 
 const (
@@ -31,38 +36,64 @@ type Service struct {
 	projectID string
 	datasetID string
 	location  string
+	labels    map[string]string
+}
+
+// Option represents service option
+type Option func(s *Service)
+
+// WithLabels returns an option setting default job labels
+func WithLabels(labels map[string]string) Option {
+	return func(s *Service) {
+		s.labels = labels
+	}
 }
 
 // NewService creates Service
-func NewService(service *bigquery.Service, projectID, datasetID, location string) *Service {
-	return &Service{
+func NewService(service *bigquery.Service, projectID, datasetID, location string, options ...Option) *Service {
+	result := &Service{
 		service:   service,
 		projectID: projectID,
 		datasetID: datasetID,
 		location:  location,
 	}
+	for _, option := range options {
+		option(result)
+	}
+	return result
 }
 
 // Ingest ingests data into a database
 func (s *Service) Ingest(ctx context.Context, SQL string) (int64, error) {
+	blocks := hint.Find(SQL)
+	if len(blocks) > 1 {
+		return 0, fmt.Errorf("multiple hints are not supported: %v", SQL)
+	}
 	aHint := hint.Extract(SQL)
 
-	SQL = s.deleteHint(SQL)
+	SQL = hint.Remove(SQL)
 
 	aIngestion, err := parse(SQL)
 	if err != nil {
 		return 0, err
 	}
 	aIngestion.Hint = aHint
+	if aHint != "" {
+		if err = applyHintOptions(aHint, aIngestion); err != nil {
+			return 0, err
+		}
+	}
 	aIngestion.Destination.init(s.projectID, s.datasetID)
 
-	switch aIngestion.Kind {
+	switch kind(strings.ToUpper(string(aIngestion.Kind))) {
 	case KindLoad:
 		return s.load(ctx, aIngestion)
 	case KindStream:
 		return s.stream(ctx, aIngestion)
+	case KindExport:
+		return s.export(ctx, aIngestion)
 	default:
-		return 0, fmt.Errorf("unsupported kind: %s, supported: [%s|%s]", aIngestion.Kind, KindLoad, KindStream)
+		return 0, fmt.Errorf("unsupported kind: %s, supported: [%s|%s|%s]", aIngestion.Kind, KindLoad, KindStream, KindExport)
 	}
 }
 
@@ -78,12 +109,33 @@ func (s *Service) load(ctx context.Context, ingestion *ingestion) (int64, error)
 
 	var aReader io.Reader
 	if isReaderRequired {
+		if aConfigLoad.SourceFormat == "DATASTORE_BACKUP" {
+			return 0, fmt.Errorf("DATASTORE_BACKUP load requires SourceUris hint option")
+		}
 		if aReader, err = reader.Get(ingestion.ReaderID); err != nil {
 			return 0, err
 		}
+		readCloser, err := loadReader(aReader, ingestion.Codec, aConfigLoad.SourceFormat)
+		if err != nil {
+			return 0, err
+		}
+		defer readCloser.Close()
+		aReader = readCloser
+	}
+
+	if aConfigLoad.CreateDisposition == createIfNeeded && aConfigLoad.Schema == nil && !aConfigLoad.Autodetect {
+		exists, err := s.tableExists(ctx, ingestion.Destination)
+		if err != nil {
+			return 0, err
+		}
+		aConfigLoad.Autodetect = !exists
 	}
 
 	job := s.createJob(aConfigLoad)
+	job.Configuration.Labels = label.Merge(s.labels, ingestion.Labels, label.FromContext(ctx))
+	if err = label.Validate(job.Configuration.Labels); err != nil {
+		return 0, err
+	}
 
 	job, err = s.submitJob(ctx, job, aReader)
 	if err != nil {
@@ -112,15 +164,33 @@ func (s *Service) stream(ctx context.Context, ingestion *ingestion) (int64, erro
 	if err != nil {
 		return 0, err
 	}
+	codec, aReader, err := resolveCodec(aReader, ingestion.Codec)
+	if err != nil {
+		return 0, err
+	}
+	readCloser, err := reader.Decompress(aReader, codec)
+	if err != nil {
+		return 0, err
+	}
+	defer readCloser.Close()
 
+	if ingestion.InsertIDField == "" {
+		ingestion.InsertIDField = reader.InsertIDField(ingestion.ReaderID)
+	}
+	if ingestion.Schema == nil {
+		ingestion.Schema = reader.Schema(ingestion.ReaderID)
+	}
 	rows := make([]*bigquery.TableDataInsertAllRequestRows, 0)
 
-	err2 := s.readRows(aReader, ingestion, &rows)
+	err2 := s.readRows(readCloser, ingestion, &rows)
 	if err2 != nil {
 		return 0, err2
 	}
-
-	return s.streamAll(ctx, rows, ingestion.Destination.TableID)
+	tableChanged, err := s.ensureTable(ctx, ingestion, rows)
+	if err != nil {
+		return 0, err
+	}
+	return s.streamAll(ctx, rows, ingestion.Destination, tableChanged)
 }
 
 func (s *Service) readRows(aReader io.Reader, ingestion *ingestion, rows *[]*bigquery.TableDataInsertAllRequestRows) error {
@@ -132,6 +202,9 @@ func (s *Service) readRows(aReader io.Reader, ingestion *ingestion, rows *[]*big
 		line, isPrefix, err := lineReader.ReadLine()
 		buffer.Write(line)
 		if err != nil {
+			if err != io.EOF {
+				return err
+			}
 			break
 		}
 		if isPrefix {
@@ -155,7 +228,7 @@ func (s *Service) readRows(aReader io.Reader, ingestion *ingestion, rows *[]*big
 	return nil
 }
 
-func (s *Service) streamAll(ctx context.Context, allRows []*bigquery.TableDataInsertAllRequestRows, tableID string) (int64, error) {
+func (s *Service) streamAll(ctx context.Context, allRows []*bigquery.TableDataInsertAllRequestRows, dest *destination, tableChanged bool) (int64, error) {
 
 	allRowsCount := int64(len(allRows))
 	offset := int64(0)
@@ -165,11 +238,7 @@ func (s *Service) streamAll(ctx context.Context, allRows []*bigquery.TableDataIn
 		rows := allRows[offset : offset+cnt]
 		offset += cnt
 
-		insertCall, err := s.streamRows(ctx, rows, tableID)
-		if err == nil {
-			err = toInsertError(insertCall.InsertErrors)
-		}
-		if err != nil {
+		if err := s.streamBatch(ctx, rows, dest, tableChanged); err != nil {
 			return 0, err
 		}
 	}
@@ -177,21 +246,31 @@ func (s *Service) streamAll(ctx context.Context, allRows []*bigquery.TableDataIn
 	return offset, nil
 }
 
-func (s *Service) streamRows(ctx context.Context, rows []*bigquery.TableDataInsertAllRequestRows, tableID string) (*bigquery.TableDataInsertAllResponse, error) {
+// streamRows inserts a batch of rows, insert errors are returned as error
+func (s *Service) streamRows(ctx context.Context, rows []*bigquery.TableDataInsertAllRequestRows, dest *destination) error {
 
 	var response *bigquery.TableDataInsertAllResponse
 	var err error
-
-	err = exec.RunWithRetries(func() error {
+	started := time.Now()
+	ctx, span := trace.Start(ctx, "bigquery.insertAll", trace.ProjectKey.String(dest.ProjectID), trace.JobKindKey.String("stream"), trace.RowsKey.Int(len(rows)))
+	err = exec.RunWithRetries(ctx, func() error {
 		insertRequest := &bigquery.TableDataInsertAllRequest{}
 		insertRequest.Rows = rows
 
-		requestCall := s.service.Tabledata.InsertAll(s.projectID, s.datasetID, tableID, insertRequest)
+		requestCall := s.service.Tabledata.InsertAll(dest.ProjectID, dest.DatasetID, dest.TableID, insertRequest)
 		response, err = requestCall.Context(ctx).Do()
 		return err
 	}, attempts)
-
-	return response, err
+	if err == nil {
+		metric.Add(metric.JobsSubmitted, 1, metric.Kind("stream"))
+		if count := len(response.InsertErrors); count > 0 {
+			metric.Add(metric.InsertErrors, float64(count), nil)
+		}
+		logging.FromContext(ctx).Batch(ctx, dest.ProjectID+"."+dest.DatasetID+"."+dest.TableID, len(rows), len(response.InsertErrors), time.Since(started))
+		err = toInsertError(response.InsertErrors)
+	}
+	trace.End(span, err)
+	return err
 }
 
 func toInsertError(insertErrors []*bigquery.TableDataInsertAllResponseInsertErrors) error {
@@ -213,13 +292,22 @@ func toInsertError(insertErrors []*bigquery.TableDataInsertAllResponseInsertErro
 }
 
 // submitJob submits job, returns affected rows count and error
-func (s *Service) submitJob(ctx context.Context, job *bigquery.Job, reader io.Reader) (*bigquery.Job, error) {
+func (s *Service) submitJob(ctx context.Context, job *bigquery.Job, reader io.Reader) (_ *bigquery.Job, err error) {
 	bigqueryService := s.service
-
+	ctx, span := trace.Start(ctx, "bigquery.submitJob", trace.ProjectKey.String(s.projectID), trace.LocationKey.String(s.location), trace.JobKindKey.String(exec.JobKind(job)))
+	defer func() {
+		if err == nil {
+			metric.Add(metric.JobsSubmitted, 1, metric.Kind(exec.JobKind(job)))
+			logging.FromContext(ctx).JobSubmitted(ctx, exec.JobKind(job), job)
+		}
+		trace.SetJob(span, job)
+		trace.End(span, err)
+	}()
 	if reader != nil {
-		return s.submitJobWithReader(ctx, job, reader, bigqueryService)
+		job, err = s.submitJobWithReader(ctx, job, reader, bigqueryService)
+		return job, err
 	}
-	err := exec.RunWithRetries(func() error {
+	err = exec.RunWithRetries(ctx, func() error {
 		var err error
 		call := bigqueryService.Jobs.Insert(s.projectID, job)
 		job, err = call.Context(ctx).Do()
@@ -233,7 +321,7 @@ func (s *Service) submitJobWithReader(ctx context.Context, job *bigquery.Job, re
 	if err != nil {
 		return nil, err
 	}
-	err = exec.RunWithRetries(func() error {
+	err = exec.RunWithRetries(ctx, func() error {
 		call := bigqueryService.Jobs.Insert(s.projectID, job)
 
 		//call = call.Media(bytes.NewBuffer(buf.Bytes()), googleapi.ContentType("application/x-gzip"))
@@ -258,6 +346,29 @@ func (s *Service) prepareBufferedReader(reader io.Reader) (*bytes.Buffer, error)
 
 }
 
+// resolveCodec returns declared codec or codec detected from reader data
+func resolveCodec(aReader io.Reader, codec string) (string, io.Reader, error) {
+	if codec != "" {
+		return codec, aReader, nil
+	}
+	return reader.DetectCodec(aReader)
+}
+
+// loadReader returns reader with upload compatible data, BigQuery accepts gzip compressed CSV and JSON, caller has to close it
+func loadReader(aReader io.Reader, codec string, sourceFormat string) (io.ReadCloser, error) {
+	codec, aReader, err := resolveCodec(aReader, codec)
+	if err != nil {
+		return nil, err
+	}
+	if codec == reader.CodecGzip {
+		switch sourceFormat {
+		case "CSV", "NEWLINE_DELIMITED_JSON":
+			return io.NopCloser(aReader), nil
+		}
+	}
+	return reader.Decompress(aReader, codec)
+}
+
 // createJob creates job
 func (s *Service) createJob(loadConfig *bigquery.JobConfigurationLoad) *bigquery.Job {
 
@@ -268,15 +379,6 @@ func (s *Service) createJob(loadConfig *bigquery.JobConfigurationLoad) *bigquery
 	}
 }
 
-func (s *Service) deleteHint(SQL string) string {
-	start := strings.Index(SQL, "/*+")
-	end := strings.Index(SQL, "+*/")
-	if start != -1 && end != -1 {
-		return SQL[:start] + SQL[end+1+2:]
-	}
-	return SQL
-}
-
 func min(x, y int64) int64 {
 	if x > y {
 		return y
@@ -0,0 +1,140 @@
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/bigquery/internal/exec"
+	"github.com/viant/bigquery/internal/schema"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/googleapi"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// settleAttempts and settleWait control streaming retries into a table created or altered just before
+var (
+	settleAttempts = 6
+	settleWait     = time.Second
+)
+
+// ensureTable creates missing destination table or applies additive schema changes before streaming,
+// table fields come from a hint or a registered reader schema, or are inferred from streamed rows,
+// it returns true if the table was created or altered
+func (s *Service) ensureTable(ctx context.Context, ingestion *ingestion, rows []*bigquery.TableDataInsertAllRequestRows) (bool, error) {
+	if ingestion.CreateDisposition != createIfNeeded && len(ingestion.SchemaUpdateOptions) == 0 {
+		return false, nil
+	}
+	dest := ingestion.Destination
+	table, err := s.getTable(ctx, dest)
+	if err != nil {
+		return false, err
+	}
+	fields := desiredFields(ingestion, rows)
+	if table == nil {
+		if ingestion.CreateDisposition != createIfNeeded {
+			return false, fmt.Errorf("table %v.%v.%v not found", dest.ProjectID, dest.DatasetID, dest.TableID)
+		}
+		if len(fields) == 0 {
+			return false, fmt.Errorf("failed to create table %v, schema was empty", dest.TableID)
+		}
+		return true, s.insertTable(ctx, dest, fields)
+	}
+	if len(ingestion.SchemaUpdateOptions) == 0 || len(fields) == 0 || table.Schema == nil {
+		return false, nil
+	}
+	options := schema.MergeOptions{
+		AllowAddition:   hasOption(ingestion.SchemaUpdateOptions, allowFieldAddition),
+		AllowRelaxation: hasOption(ingestion.SchemaUpdateOptions, allowFieldRelaxation),
+	}
+	merged, changed := schema.Merge(table.Schema.Fields, fields, options)
+	if !changed {
+		return false, nil
+	}
+	return true, s.patchTableSchema(ctx, dest, table.Etag, merged)
+}
+
+// streamBatch streams rows, if the table was created or altered just before, streaming may report the table
+// as not found or reject new fields until the change propagates, such errors are retried with backoff
+func (s *Service) streamBatch(ctx context.Context, rows []*bigquery.TableDataInsertAllRequestRows, dest *destination, tableChanged bool) error {
+	wait := settleWait
+	for i := 1; ; i++ {
+		err := s.streamRows(ctx, rows, dest)
+		if err == nil || !tableChanged || i == settleAttempts || !isSettling(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+// isSettling returns true for streaming errors caused by not yet propagated table creation or schema change,
+// rejected requests insert no rows, thus they can be retried
+func isSettling(err error) bool {
+	return exec.IsNotFound(err) || strings.Contains(err.Error(), "no such field")
+}
+
+// tableExists returns true if destination table exists
+func (s *Service) tableExists(ctx context.Context, dest *destination) (bool, error) {
+	table, err := s.getTable(ctx, dest)
+	return table != nil, err
+}
+
+// getTable returns destination table or nil if table does not exist
+func (s *Service) getTable(ctx context.Context, dest *destination) (*bigquery.Table, error) {
+	var table *bigquery.Table
+	err := exec.RunWithRetries(ctx, func() error {
+		var err error
+		table, err = s.service.Tables.Get(dest.ProjectID, dest.DatasetID, dest.TableID).Context(ctx).Do()
+		return err
+	}, attempts)
+	if exec.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table %v.%v.%v, %w", dest.ProjectID, dest.DatasetID, dest.TableID, err)
+	}
+	return table, nil
+}
+
+func (s *Service) insertTable(ctx context.Context, dest *destination, fields []*bigquery.TableFieldSchema) error {
+	table := &bigquery.Table{
+		TableReference: &bigquery.TableReference{ProjectId: dest.ProjectID, DatasetId: dest.DatasetID, TableId: dest.TableID},
+		Schema:         &bigquery.TableSchema{Fields: fields},
+	}
+	_, err := s.service.Tables.Insert(dest.ProjectID, dest.DatasetID, table).Context(ctx).Do()
+	if apiError, ok := err.(*googleapi.Error); ok && apiError.Code == http.StatusConflict {
+		return nil //table created concurrently
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create table %v.%v.%v, %w", dest.ProjectID, dest.DatasetID, dest.TableID, err)
+	}
+	return nil
+}
+
+func (s *Service) patchTableSchema(ctx context.Context, dest *destination, etag string, fields []*bigquery.TableFieldSchema) error {
+	call := s.service.Tables.Patch(dest.ProjectID, dest.DatasetID, dest.TableID, &bigquery.Table{Schema: &bigquery.TableSchema{Fields: fields}})
+	if etag != "" {
+		call.Header().Set("If-Match", etag)
+	}
+	if _, err := call.Context(ctx).Do(); err != nil {
+		return fmt.Errorf("failed to update table %v.%v.%v schema, %w", dest.ProjectID, dest.DatasetID, dest.TableID, err)
+	}
+	return nil
+}
+
+// desiredFields returns table fields defined by a hint or a registered reader, or inferred from rows
+func desiredFields(ingestion *ingestion, rows []*bigquery.TableDataInsertAllRequestRows) []*bigquery.TableFieldSchema {
+	if ingestion.Schema != nil {
+		return ingestion.Schema.Fields
+	}
+	var records = make([]map[string]bigquery.JsonValue, len(rows))
+	for i, row := range rows {
+		records[i] = row.Json
+	}
+	return schema.FieldsOfJSON(records)
+}
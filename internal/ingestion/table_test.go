@@ -0,0 +1,60 @@
+package ingestion
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+)
+
+func TestService_StreamBatch(t *testing.T) {
+	settleWait = time.Millisecond
+	defer func() { settleWait = time.Second }()
+	notFound := `404 {"error": {"code": 404, "message": "Not found: Table p:d.t"}}`
+	noSuchField := `200 {"insertErrors": [{"index": 0, "errors": [{"reason": "invalid", "message": "no such field: score."}]}]}`
+	inserted := `200 {}`
+	var testCases = []struct {
+		description  string
+		responses    []string
+		tableChanged bool
+		expectCalls  int32
+		hasError     bool
+	}{
+		{description: "created table not found yet", responses: []string{notFound, notFound, inserted}, tableChanged: true, expectCalls: 3},
+		{description: "added field not propagated yet", responses: []string{noSuchField, inserted}, tableChanged: true, expectCalls: 2},
+		{description: "unchanged table is not retried", responses: []string{notFound}, expectCalls: 1, hasError: true},
+		{description: "settle attempts exhausted", responses: []string{notFound}, tableChanged: true, expectCalls: int32(settleAttempts), hasError: true},
+	}
+	for _, testCase := range testCases {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			index := int(atomic.AddInt32(&calls, 1)) - 1
+			if index >= len(testCase.responses) {
+				index = len(testCase.responses) - 1
+			}
+			response := testCase.responses[index]
+			w.Header().Set("Content-Type", "application/json")
+			if response[:3] == "404" {
+				w.WriteHeader(http.StatusNotFound)
+			}
+			_, _ = w.Write([]byte(response[4:]))
+		}))
+		service, err := bigquery.NewService(context.Background(), option.WithHTTPClient(server.Client()), option.WithEndpoint(server.URL))
+		if !assert.NoError(t, err, testCase.description) {
+			server.Close()
+			continue
+		}
+		srv := &Service{service: service}
+		rows := []*bigquery.TableDataInsertAllRequestRows{{Json: map[string]bigquery.JsonValue{"id": 1}}}
+		err = srv.streamBatch(context.Background(), rows, &destination{ProjectID: "p", DatasetID: "d", TableID: "t"}, testCase.tableChanged)
+		server.Close()
+		assert.Equal(t, testCase.hasError, err != nil, testCase.description)
+		assert.Equal(t, testCase.expectCalls, atomic.LoadInt32(&calls), testCase.description)
+	}
+}
@@ -0,0 +1,110 @@
+package label
+
+import (
+	"context"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	//MaxCount maximum number of labels per job
+	MaxCount = 64
+	//MaxLength maximum label key or value length
+	MaxLength = 63
+)
+
+type contextKey struct{}
+
+// NewContext returns a context carrying labels merged with labels already present in ctx
+func NewContext(ctx context.Context, labels map[string]string) context.Context {
+	return context.WithValue(ctx, contextKey{}, Merge(FromContext(ctx), labels))
+}
+
+// FromContext returns labels carried by ctx
+func FromContext(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	labels, _ := ctx.Value(contextKey{}).(map[string]string)
+	return labels
+}
+
+// Merge merges label sets, labels from latter sets override former ones
+func Merge(sets ...map[string]string) map[string]string {
+	var result map[string]string
+	for _, set := range sets {
+		if len(set) == 0 {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]string, len(set))
+		}
+		for k, v := range set {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// Validate checks labels against BigQuery label requirements
+// see https://cloud.google.com/bigquery/docs/labels-intro#requirements
+func Validate(labels map[string]string) error {
+	if len(labels) > MaxCount {
+		return fmt.Errorf("too many labels: %v, max allowed: %v", len(labels), MaxCount)
+	}
+	for k, v := range labels {
+		if k == "" {
+			return fmt.Errorf("invalid label key: key was empty")
+		}
+		if err := validate(k); err != nil {
+			return fmt.Errorf("invalid label key: %q, %w", k, err)
+		}
+		if first, _ := utf8.DecodeRuneInString(k); !unicode.IsLetter(first) || unicode.IsUpper(first) {
+			return fmt.Errorf("invalid label key: %q, key has to start with a lowercase letter", k)
+		}
+		if err := validate(v); err != nil {
+			return fmt.Errorf("invalid label %v value: %q, %w", k, v, err)
+		}
+	}
+	return nil
+}
+
+// SanitizeValue returns value usable as label value, upper case letters are lower cased,
+// unsupported characters replaced with '_' and value truncated to MaxLength characters
+func SanitizeValue(value string) string {
+	var result = make([]rune, 0, len(value))
+	for _, r := range value {
+		if len(result) == MaxLength {
+			break
+		}
+		switch {
+		case r == '_', r == '-', unicode.IsDigit(r):
+		case unicode.IsLetter(r):
+			r = unicode.ToLower(r)
+			if unicode.IsUpper(r) {
+				r = '_'
+			}
+		default:
+			r = '_'
+		}
+		result = append(result, r)
+	}
+	return string(result)
+}
+
+func validate(text string) error {
+	if count := utf8.RuneCountInString(text); count > MaxLength {
+		return fmt.Errorf("length %v exceeds %v characters", count, MaxLength)
+	}
+	for _, r := range text {
+		switch {
+		case r == '_', r == '-':
+		case unicode.IsDigit(r):
+		case unicode.IsLetter(r) && !unicode.IsUpper(r):
+		default:
+			return fmt.Errorf("unsupported character: %q, only lowercase letters, digits, '_' and '-' are allowed", r)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package label
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	var testCases = []struct {
+		description string
+		labels      map[string]string
+		hasError    bool
+	}{
+		{
+			description: "valid labels",
+			labels:      map[string]string{"team": "ads", "cost_center": "cc-101", "env": ""},
+		},
+		{
+			description: "international characters",
+			labels:      map[string]string{"équipe": "données"},
+		},
+		{
+			description: "empty key",
+			labels:      map[string]string{"": "ads"},
+			hasError:    true,
+		},
+		{
+			description: "upper case key",
+			labels:      map[string]string{"Team": "ads"},
+			hasError:    true,
+		},
+		{
+			description: "key starting with digit",
+			labels:      map[string]string{"1team": "ads"},
+			hasError:    true,
+		},
+		{
+			description: "upper case value",
+			labels:      map[string]string{"team": "Ads"},
+			hasError:    true,
+		},
+		{
+			description: "unsupported character",
+			labels:      map[string]string{"team": "ads.api"},
+			hasError:    true,
+		},
+		{
+			description: "value too long",
+			labels:      map[string]string{"team": strings.Repeat("a", MaxLength+1)},
+			hasError:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := Validate(testCase.labels)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		assert.Nil(t, err, testCase.description)
+	}
+}
+
+func TestNewContext(t *testing.T) {
+	ctx := NewContext(context.Background(), map[string]string{"team": "ads", "service": "api"})
+	ctx = NewContext(ctx, map[string]string{"service": "batch"})
+	assert.EqualValues(t, map[string]string{"team": "ads", "service": "batch"}, FromContext(ctx))
+	assert.Nil(t, FromContext(context.Background()))
+}
+
+func TestMerge(t *testing.T) {
+	assert.Nil(t, Merge(nil, map[string]string{}))
+	assert.EqualValues(t, map[string]string{"app": "svc", "team": "ads"}, Merge(map[string]string{"app": "go-sql-bq"}, map[string]string{"app": "svc", "team": "ads"}))
+}
+
+func TestSanitizeValue(t *testing.T) {
+	var testCases = []struct {
+		value  string
+		expect string
+	}{
+		{value: "go-sql-bq", expect: "go-sql-bq"},
+		{value: "MyService/1.2", expect: "myservice_1_2"},
+		{value: "svc.v2", expect: "svc_v2"},
+		{value: "Équipe", expect: "équipe"},
+		{value: strings.Repeat("a", MaxLength+5), expect: strings.Repeat("a", MaxLength)},
+	}
+	for _, testCase := range testCases {
+		actual := SanitizeValue(testCase.value)
+		assert.Equal(t, testCase.expect, actual, testCase.value)
+		assert.Nil(t, Validate(map[string]string{"app": actual}), testCase.value)
+	}
+}
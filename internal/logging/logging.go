@@ -0,0 +1,195 @@
+// Package logging provides log/slog based driver logging.
+//
+// The driver is silent unless a connector logger is configured or a logger is attached to the request context.
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"google.golang.org/api/bigquery/v2"
+)
+
+// Redaction controls which parts of the logged SQL are redacted
+type Redaction int
+
+const (
+	// RedactLiterals replaces string and numeric SQL literals with ?
+	RedactLiterals = Redaction(1 << iota)
+	// RedactParameters logs query parameter names and types without values
+	RedactParameters
+)
+
+// Has returns true if redaction includes flag
+func (r Redaction) Has(flag Redaction) bool {
+	return r&flag == flag
+}
+
+// Logger represents driver logger
+type Logger struct {
+	*slog.Logger
+	Redaction Redaction
+}
+
+type (
+	defaultKey  struct{}
+	overrideKey struct{}
+)
+
+var discard = &Logger{Logger: slog.New(discardHandler{})}
+
+// New creates a logger, nil slog logger returns nil
+func New(logger *slog.Logger, redaction Redaction) *Logger {
+	if logger == nil {
+		return nil
+	}
+	return &Logger{Logger: logger, Redaction: redaction}
+}
+
+// WithDefault returns a context carrying connector logger, nil logger returns ctx
+func WithDefault(ctx context.Context, logger *Logger) context.Context {
+	if logger == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, defaultKey{}, logger)
+}
+
+// NewContext returns a context carrying logger overriding the connector one
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, overrideKey{}, logger)
+}
+
+// FromContext returns logger carried by ctx, the context override takes precedence over the connector logger,
+// but keeps the connector redaction, a discarding logger is returned if none is present
+func FromContext(ctx context.Context) *Logger {
+	if ctx == nil {
+		return discard
+	}
+	result, _ := ctx.Value(defaultKey{}).(*Logger)
+	if result == nil {
+		result = discard
+	}
+	if override, _ := ctx.Value(overrideKey{}).(*slog.Logger); override != nil {
+		return &Logger{Logger: override, Redaction: result.Redaction}
+	}
+	return result
+}
+
+// JobSubmitted logs submitted job
+func (l *Logger) JobSubmitted(ctx context.Context, kind string, job *bigquery.Job) {
+	if job == nil || !l.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	attrs := append(jobAttrs(job), slog.String("kind", kind))
+	if config := job.Configuration; config != nil && config.Query != nil {
+		SQL := config.Query.Query
+		if l.Redaction.Has(RedactLiterals) {
+			SQL = Literals(SQL)
+		}
+		attrs = append(attrs, slog.String("sql", SQL))
+		if len(config.Query.QueryParameters) > 0 {
+			attrs = append(attrs, slog.Any("params", l.parameters(config.Query.QueryParameters)))
+		}
+		if config.DryRun {
+			attrs = append(attrs, slog.Bool("dry_run", true))
+		}
+	}
+	l.LogAttrs(ctx, slog.LevelDebug, "bigquery job submitted", attrs...)
+}
+
+// JobCompleted logs completed job with its statistics, failed jobs are logged with warning level
+func (l *Logger) JobCompleted(ctx context.Context, kind string, job *bigquery.Job, elapsed time.Duration) {
+	level := slog.LevelInfo
+	if job.Status != nil && job.Status.ErrorResult != nil {
+		level = slog.LevelWarn
+	}
+	if !l.Enabled(ctx, level) {
+		return
+	}
+	attrs := append(jobAttrs(job), slog.String("kind", kind), slog.Duration("elapsed", elapsed))
+	if stats := job.Statistics; stats != nil {
+		if stats.TotalBytesProcessed > 0 {
+			attrs = append(attrs, slog.Int64("bytes_processed", stats.TotalBytesProcessed))
+		}
+		if query := stats.Query; query != nil {
+			attrs = append(attrs, slog.Int64("bytes_billed", query.TotalBytesBilled), slog.Bool("cache_hit", query.CacheHit))
+			if query.NumDmlAffectedRows > 0 {
+				attrs = append(attrs, slog.Int64("affected_rows", query.NumDmlAffectedRows))
+			}
+		}
+		if load := stats.Load; load != nil {
+			attrs = append(attrs, slog.Int64("output_rows", load.OutputRows))
+		}
+	}
+	if level == slog.LevelWarn {
+		attrs = append(attrs, slog.String("error", job.Status.ErrorResult.Message))
+	}
+	l.LogAttrs(ctx, level, "bigquery job completed", attrs...)
+}
+
+// Retry logs retried call
+func (l *Logger) Retry(ctx context.Context, attempt int, reason string, backoff time.Duration, err error) {
+	l.LogAttrs(ctx, slog.LevelWarn, "bigquery call retried",
+		slog.Int("attempt", attempt), slog.String("reason", reason), slog.Duration("backoff", backoff), slog.Any("error", err))
+}
+
+// Batch logs streamed rows batch
+func (l *Logger) Batch(ctx context.Context, table string, rows int, insertErrors int, elapsed time.Duration) {
+	level := slog.LevelDebug
+	if insertErrors > 0 {
+		level = slog.LevelWarn
+	}
+	l.LogAttrs(ctx, level, "bigquery batch streamed",
+		slog.String("table", table), slog.Int("rows", rows), slog.Int("insert_errors", insertErrors), slog.Duration("elapsed", elapsed))
+}
+
+// parameters returns loggable query parameters, values are omitted with RedactParameters
+func (l *Logger) parameters(params []*bigquery.QueryParameter) []string {
+	var result = make([]string, len(params))
+	for i, param := range params {
+		name := param.Name
+		if name == "" {
+			name = "$" + strconv.Itoa(i+1)
+		}
+		if param.ParameterType != nil {
+			name += ":" + param.ParameterType.Type
+		}
+		if !l.Redaction.Has(RedactParameters) {
+			name += "=" + parameterValue(param.ParameterValue)
+		}
+		result[i] = name
+	}
+	return result
+}
+
+func parameterValue(value *bigquery.QueryParameterValue) string {
+	if value == nil {
+		return "NULL"
+	}
+	if len(value.ArrayValues) == 0 && len(value.StructValues) == 0 {
+		return value.Value
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+func jobAttrs(job *bigquery.Job) []slog.Attr {
+	var result []slog.Attr
+	if ref := job.JobReference; ref != nil {
+		result = append(result, slog.String("project", ref.ProjectId), slog.String("location", ref.Location), slog.String("job_id", ref.JobId))
+	}
+	if status := job.Status; status != nil && status.State != "" {
+		result = append(result, slog.String("state", status.State))
+	}
+	return result
+}
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (d discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discardHandler) WithGroup(string) slog.Handler           { return d }
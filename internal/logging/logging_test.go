@@ -0,0 +1,110 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/bigquery/v2"
+)
+
+func TestLiterals(t *testing.T) {
+	var testCases = []struct {
+		description string
+		SQL         string
+		expect      string
+	}{
+		{
+			description: "string and numeric literals",
+			SQL:         "SELECT * FROM t WHERE name = 'john' AND age > 21 AND score = 1.5e-3",
+			expect:      "SELECT * FROM t WHERE name = ? AND age > ? AND score = ?",
+		},
+		{
+			description: "escaped and triple quoted strings",
+			SQL:         `SELECT 'it\'s', """multi 'line'""", "x" FROM t`,
+			expect:      "SELECT ?, ?, ? FROM t",
+		},
+		{
+			description: "identifiers, parameters and comments are kept",
+			SQL:         "SELECT c1 FROM `p.ds1.t2` /*+ {\"x\": 1} +*/ WHERE id = @id AND v = ? -- 'note'\nLIMIT 10",
+			expect:      "SELECT c1 FROM `p.ds1.t2` /*+ {\"x\": 1} +*/ WHERE id = @id AND v = ? -- 'note'\nLIMIT ?",
+		},
+		{
+			description: "unterminated string",
+			SQL:         "SELECT 'abc",
+			expect:      "SELECT ?",
+		},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, Literals(testCase.SQL), testCase.description)
+	}
+}
+
+func TestLogger_JobSubmitted(t *testing.T) {
+	job := &bigquery.Job{
+		JobReference: &bigquery.JobReference{ProjectId: "p", Location: "US", JobId: "j1"},
+		Configuration: &bigquery.JobConfiguration{Query: &bigquery.JobConfigurationQuery{
+			Query: "SELECT * FROM t WHERE id = @id AND name = 'x'",
+			QueryParameters: []*bigquery.QueryParameter{
+				{Name: "id", ParameterType: &bigquery.QueryParameterType{Type: "INT64"}, ParameterValue: &bigquery.QueryParameterValue{Value: "42"}},
+			},
+		}},
+	}
+	var testCases = []struct {
+		description string
+		redaction   Redaction
+		expect      []string
+		notExpect   []string
+	}{
+		{
+			description: "no redaction",
+			expect:      []string{"job_id=j1", "kind=query", "name = 'x'", "id:INT64=42"},
+		},
+		{
+			description: "literals redaction",
+			redaction:   RedactLiterals,
+			expect:      []string{"name = ?", "id:INT64=42"},
+			notExpect:   []string{"'x'"},
+		},
+		{
+			description: "parameters redaction",
+			redaction:   RedactLiterals | RedactParameters,
+			expect:      []string{"name = ?", "id:INT64"},
+			notExpect:   []string{"INT64=42"},
+		},
+	}
+	for _, testCase := range testCases {
+		buffer := new(bytes.Buffer)
+		logger := New(slog.New(slog.NewTextHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug})), testCase.redaction)
+		ctx := WithDefault(context.Background(), logger)
+		FromContext(ctx).JobSubmitted(ctx, "query", job)
+		for _, expect := range testCase.expect {
+			assert.Contains(t, buffer.String(), expect, testCase.description)
+		}
+		for _, notExpect := range testCase.notExpect {
+			assert.NotContains(t, buffer.String(), notExpect, testCase.description)
+		}
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	connectorOutput := new(bytes.Buffer)
+	overrideOutput := new(bytes.Buffer)
+	connector := New(slog.New(slog.NewTextHandler(connectorOutput, nil)), RedactLiterals)
+	override := slog.New(slog.NewTextHandler(overrideOutput, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	assert.False(t, FromContext(context.Background()).Enabled(context.Background(), slog.LevelError), "discard by default")
+
+	ctx := WithDefault(context.Background(), connector)
+	FromContext(ctx).Retry(ctx, 1, "backendError", 0, nil)
+	assert.Contains(t, connectorOutput.String(), "reason=backendError")
+
+	ctx = NewContext(ctx, override)
+	logger := FromContext(ctx)
+	assert.Equal(t, RedactLiterals, logger.Redaction, "override keeps connector redaction")
+	logger.Batch(ctx, "p.ds.t", 10, 0, 0)
+	assert.Contains(t, overrideOutput.String(), "rows=10")
+	assert.NotContains(t, connectorOutput.String(), "rows=10")
+}
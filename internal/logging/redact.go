@@ -0,0 +1,21 @@
+package logging
+
+import (
+	"github.com/viant/bigquery/internal/sqlscan"
+	"strings"
+)
+
+// Literals returns SQL with string and numeric literals replaced with ?, quoted identifiers and comments are kept
+func Literals(SQL string) string {
+	var result strings.Builder
+	result.Grow(len(SQL))
+	for _, fragment := range sqlscan.Scan(SQL) {
+		switch fragment.Code {
+		case sqlscan.StringLiteral, sqlscan.NumericLiteral:
+			result.WriteByte('?')
+		default:
+			result.WriteString(SQL[fragment.Begin:fragment.End])
+		}
+	}
+	return result.String()
+}
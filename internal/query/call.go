@@ -158,3 +158,8 @@ func NewResultsCall(call *bigquery.JobsGetQueryResultsCall, session *internal.Se
 	res := (*nativeCall)(unsafe.Pointer(call))
 	return &ResultsCall{nativeCall: res, session: session}
 }
+
+// HTTPClient returns service http client
+func HTTPClient(service *bigquery.Service) *http.Client {
+	return (&nativeCall{s: service}).httpClient()
+}
@@ -265,6 +265,15 @@ func (s *TableFieldSchema) UnmarshalJSONObject(dec *gojay.Decoder, k string) err
 	case "description":
 		return dec.String(&s.Description)
 
+	case "maxLength":
+		return decodeInt64(dec, &s.MaxLength)
+
+	case "precision":
+		return decodeInt64(dec, &s.Precision)
+
+	case "scale":
+		return decodeInt64(dec, &s.Scale)
+
 	case "fields":
 		var aSlice = TableFieldSchemasPtr{}
 		err := dec.Array(&aSlice)
@@ -295,7 +304,7 @@ func (s *TableFieldSchema) UnmarshalJSONObject(dec *gojay.Decoder, k string) err
 }
 
 // NKeys returns the number of keys to unmarshal
-func (s *TableFieldSchema) NKeys() int { return 7 }
+func (s *TableFieldSchema) NKeys() int { return 10 }
 
 // UnmarshalJSONObject implements gojay's UnmarshalerJSONObject
 func (c *TableFieldSchemaCategories) UnmarshalJSONObject(dec *gojay.Decoder, k string) error {
@@ -0,0 +1,166 @@
+package schema
+
+import (
+	"fmt"
+	"google.golang.org/api/bigquery/v2"
+	"reflect"
+)
+
+const (
+	modeNullable = "NULLABLE"
+	modeRequired = "REQUIRED"
+	modeRepeated = "REPEATED"
+)
+
+var inferTypes = map[string]bool{
+	"STRING": true, "BYTES": true, "INTEGER": true, "INT64": true, "FLOAT": true, "FLOAT64": true,
+	"NUMERIC": true, "BIGNUMERIC": true, "BOOLEAN": true, "BOOL": true, "TIMESTAMP": true, "DATE": true,
+	"TIME": true, "DATETIME": true, "GEOGRAPHY": true, "JSON": true,
+}
+
+// StructField represents Go struct field mapped to BigQuery table field
+type StructField struct {
+	Index  []int
+	Tag    *Tag
+	Type   reflect.Type //field value type without pointer and slice
+	Schema *bigquery.TableFieldSchema
+	Fields []*StructField //RECORD fields
+}
+
+// TableSchemaOf builds table schema from a Go struct type, slices are mapped to REPEATED, structs to RECORD
+// and other fields to NULLABLE mode, REQUIRED mode is opt-in with required tag option, thus derived schema
+// matches existing NULLABLE columns; field name, mode and type can be customized with bigquery tag.
+func TableSchemaOf(rType reflect.Type) (*bigquery.TableSchema, error) {
+	fields, err := StructFields(rType)
+	if err != nil {
+		return nil, err
+	}
+	return &bigquery.TableSchema{Fields: fieldsSchema(fields)}, nil
+}
+
+// StructFields returns struct fields mapped to BigQuery table fields
+func StructFields(rType reflect.Type) ([]*StructField, error) {
+	for rType.Kind() == reflect.Ptr || rType.Kind() == reflect.Slice {
+		rType = rType.Elem()
+	}
+	if rType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported schema type: %v, expected struct", rType)
+	}
+	return structFields(rType, nil)
+}
+
+func fieldsSchema(fields []*StructField) []*bigquery.TableFieldSchema {
+	var result = make([]*bigquery.TableFieldSchema, len(fields))
+	for i, field := range fields {
+		result[i] = field.Schema
+	}
+	return result
+}
+
+func structFields(rType reflect.Type, index []int) ([]*StructField, error) {
+	var result []*StructField
+	for i := 0; i < rType.NumField(); i++ {
+		field := rType.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		tag := ParseTag(field)
+		if tag.Skip {
+			continue
+		}
+		fieldIndex := append(append([]int{}, index...), i)
+		if field.Anonymous && field.Tag.Get(TagName) == "" && field.Type.Kind() == reflect.Struct && field.Type != timeType {
+			embedded, err := structFields(field.Type, fieldIndex)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, embedded...)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		structField, err := newStructField(tag, field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build schema for field: %v, %w", field.Name, err)
+		}
+		structField.Index = fieldIndex
+		result = append(result, structField)
+	}
+	return result, nil
+}
+
+func newStructField(tag *Tag, rType reflect.Type) (*StructField, error) {
+	result := &StructField{Tag: tag, Schema: &bigquery.TableFieldSchema{Name: tag.Name, Mode: modeNullable}}
+	fieldSchema := result.Schema
+	if rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	if rType.Kind() == reflect.Slice && rType != bytesType {
+		fieldSchema.Mode = modeRepeated
+		rType = indirect(rType.Elem())
+		if rType.Kind() == reflect.Slice && rType != bytesType {
+			return nil, fmt.Errorf("unsupported nested repeated type: %v", rType)
+		}
+	}
+	result.Type = rType
+	if fieldSchema.Mode != modeRepeated && tag.Required {
+		fieldSchema.Mode = modeRequired
+	}
+	if tag.Type != "" {
+		if !inferTypes[tag.Type] {
+			return nil, fmt.Errorf("unsupported tag type: %v", tag.Type)
+		}
+		fieldSchema.Type = tag.Type
+		return result, nil
+	}
+	if rType.Kind() == reflect.Struct && rType != timeType && rType != ratType {
+		fields, err := structFields(rType, nil)
+		if err != nil {
+			return nil, err
+		}
+		fieldSchema.Type = string(FieldTypeRecord)
+		fieldSchema.Fields = fieldsSchema(fields)
+		result.Fields = fields
+		return result, nil
+	}
+	fieldType, err := mapGoType(rType)
+	if err != nil {
+		return nil, err
+	}
+	fieldSchema.Type = string(fieldType)
+	return result, nil
+}
+
+// mapGoType maps Go type to BigQuery type, inverse of mapBasicRawType, uint64 exceeds INTEGER range thus it is mapped to NUMERIC
+func mapGoType(rType reflect.Type) (FieldType, error) {
+	switch rType {
+	case bytesType:
+		return FieldTypeBytes, nil
+	case timeType:
+		return FieldTypeTimestamp, nil
+	case ratType:
+		return FieldTypeBigNumeric, nil
+	}
+	switch rType.Kind() {
+	case reflect.String:
+		return FieldTypeString, nil
+	case reflect.Bool:
+		return FieldTypeBoolean, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return FieldTypeInteger, nil
+	case reflect.Uint64:
+		return FieldTypeNumeric, nil
+	case reflect.Float32, reflect.Float64:
+		return FieldTypeFloat, nil
+	}
+	return "", fmt.Errorf("unsupported type: %v", rType)
+}
+
+func indirect(rType reflect.Type) reflect.Type {
+	if rType.Kind() == reflect.Ptr {
+		return rType.Elem()
+	}
+	return rType
+}
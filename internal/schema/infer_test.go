@@ -0,0 +1,97 @@
+package schema
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/bigquery/v2"
+)
+
+func TestTableSchemaOf(t *testing.T) {
+	type Audit struct {
+		Created time.Time
+		Updated *time.Time
+	}
+	type Param struct {
+		Key   string  `json:"key"`
+		Value *string `json:"value,omitempty"`
+	}
+	type Record struct {
+		ID      int `bigquery:"id,required"`
+		Name    string
+		Counter uint64
+		Comment string `bigquery:"comment,nullable"`
+		Amount  *float64
+		Price   *big.Rat  `bigquery:"price,type=NUMERIC"`
+		Day     time.Time `bigquery:",type=DATE"`
+		Payload []byte
+		Tags    []string
+		Params  []*Param
+		Audit
+		internal int
+		Ignored  string `bigquery:"-"`
+	}
+
+	var testCases = []struct {
+		description string
+		record      interface{}
+		expect      *bigquery.TableSchema
+		hasError    bool
+	}{
+		{
+			description: "nested and repeated types",
+			record:      []*Record{},
+			expect: &bigquery.TableSchema{Fields: []*bigquery.TableFieldSchema{
+				{Name: "id", Type: "INTEGER", Mode: "REQUIRED"},
+				{Name: "Name", Type: "STRING", Mode: "NULLABLE"},
+				{Name: "Counter", Type: "NUMERIC", Mode: "NULLABLE"},
+				{Name: "comment", Type: "STRING", Mode: "NULLABLE"},
+				{Name: "Amount", Type: "FLOAT", Mode: "NULLABLE"},
+				{Name: "price", Type: "NUMERIC", Mode: "NULLABLE"},
+				{Name: "Day", Type: "DATE", Mode: "NULLABLE"},
+				{Name: "Payload", Type: "BYTES", Mode: "NULLABLE"},
+				{Name: "Tags", Type: "STRING", Mode: "REPEATED"},
+				{Name: "Params", Type: "RECORD", Mode: "REPEATED", Fields: []*bigquery.TableFieldSchema{
+					{Name: "key", Type: "STRING", Mode: "NULLABLE"},
+					{Name: "value", Type: "STRING", Mode: "NULLABLE"},
+				}},
+				{Name: "Created", Type: "TIMESTAMP", Mode: "NULLABLE"},
+				{Name: "Updated", Type: "TIMESTAMP", Mode: "NULLABLE"},
+			}},
+		},
+		{
+			description: "non struct type",
+			record:      "abc",
+			hasError:    true,
+		},
+		{
+			description: "unsupported map type",
+			record: struct {
+				Attrs map[string]string
+			}{},
+			hasError: true,
+		},
+		{
+			description: "unsupported tag type",
+			record: struct {
+				ID int `bigquery:"id,type=UUID"`
+			}{},
+			hasError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := TableSchemaOf(reflect.TypeOf(testCase.record))
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+	}
+}
@@ -0,0 +1,97 @@
+package schema
+
+import (
+	"google.golang.org/api/bigquery/v2"
+	"math"
+	"sort"
+)
+
+// FieldsOfJSON infers table fields from decoded JSON records, fields are NULLABLE as with BigQuery schema auto-detection,
+// thus later records may omit them, arrays are mapped to REPEATED and objects to RECORD fields ordered by name.
+func FieldsOfJSON(records []map[string]bigquery.JsonValue) []*bigquery.TableFieldSchema {
+	var objects = make([]map[string]interface{}, len(records))
+	for i, record := range records {
+		object := make(map[string]interface{}, len(record))
+		for k, v := range record {
+			object[k] = v
+		}
+		objects[i] = object
+	}
+	return inferObjects(objects)
+}
+
+func inferObjects(objects []map[string]interface{}) []*bigquery.TableFieldSchema {
+	var result []*bigquery.TableFieldSchema
+	var fields = map[string]*bigquery.TableFieldSchema{}
+	var nested = map[string][]map[string]interface{}{}
+	for _, object := range objects {
+		var names = make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			value := object[name]
+			field, ok := fields[name]
+			if !ok {
+				field = &bigquery.TableFieldSchema{Name: name, Mode: modeNullable}
+				fields[name] = field
+				result = append(result, field)
+			}
+			if value == nil {
+				continue
+			}
+			if values, ok := value.([]interface{}); ok {
+				field.Mode = modeRepeated
+				for _, item := range values {
+					nested[name] = inferValue(field, item, nested[name])
+				}
+				continue
+			}
+			nested[name] = inferValue(field, value, nested[name])
+		}
+	}
+	for _, field := range result {
+		if objects := nested[field.Name]; len(objects) > 0 && field.Type == string(FieldTypeRecord) {
+			field.Fields = inferObjects(objects)
+		}
+		if field.Type == "" {
+			field.Type = string(FieldTypeString)
+		}
+	}
+	return result
+}
+
+// inferValue updates field type with JSON value type, returns nested objects extended with value if it is an object
+func inferValue(field *bigquery.TableFieldSchema, value interface{}, objects []map[string]interface{}) []map[string]interface{} {
+	var valueType FieldType
+	switch actual := value.(type) {
+	case nil:
+		return objects
+	case map[string]interface{}:
+		valueType = FieldTypeRecord
+		objects = append(objects, actual)
+	case bool:
+		valueType = FieldTypeBoolean
+	case float64:
+		valueType = FieldTypeFloat
+		if actual == math.Trunc(actual) && math.Abs(actual) < 1<<53 {
+			valueType = FieldTypeInteger
+		}
+	case string:
+		valueType = FieldTypeString
+	default:
+		valueType = "JSON"
+	}
+	switch {
+	case field.Type == "":
+		field.Type = string(valueType)
+	case field.Type == string(valueType):
+	case field.Type == string(FieldTypeInteger) && valueType == FieldTypeFloat:
+		field.Type = string(FieldTypeFloat)
+	case field.Type == string(FieldTypeFloat) && valueType == FieldTypeInteger:
+	default:
+		field.Type = string(FieldTypeString)
+	}
+	return objects
+}
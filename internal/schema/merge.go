@@ -0,0 +1,73 @@
+package schema
+
+import (
+	"google.golang.org/api/bigquery/v2"
+	"strings"
+)
+
+// MergeOptions represents additive schema change options
+type MergeOptions struct {
+	AllowAddition   bool
+	AllowRelaxation bool
+}
+
+// Merge returns existing table fields with additive changes from desired fields applied,
+// new fields are added as NULLABLE (or REPEATED), REQUIRED fields desired as NULLABLE or missing from desired fields
+// are relaxed to NULLABLE. Existing field types are never changed. Changed reports whether result differs from existing.
+func Merge(existing, desired []*bigquery.TableFieldSchema, options MergeOptions) ([]*bigquery.TableFieldSchema, bool) {
+	var result = make([]*bigquery.TableFieldSchema, 0, len(existing)+len(desired))
+	var desiredByName = make(map[string]*bigquery.TableFieldSchema, len(desired))
+	for _, field := range desired {
+		desiredByName[strings.ToLower(field.Name)] = field
+	}
+	changed := false
+	var merged = make(map[string]bool, len(existing))
+	for _, field := range existing {
+		key := strings.ToLower(field.Name)
+		merged[key] = true
+		desiredField := desiredByName[key]
+		mergedField := *field
+		if options.AllowRelaxation && field.Mode == modeRequired && (desiredField == nil || desiredField.Mode != modeRequired) {
+			mergedField.Mode = modeNullable
+			changed = true
+		}
+		if desiredField != nil && isRecord(field.Type) && isRecord(desiredField.Type) {
+			fields, fieldsChanged := Merge(field.Fields, desiredField.Fields, options)
+			if fieldsChanged {
+				mergedField.Fields = fields
+				changed = true
+			}
+		}
+		result = append(result, &mergedField)
+	}
+	if !options.AllowAddition {
+		return result, changed
+	}
+	for _, field := range desired {
+		if merged[strings.ToLower(field.Name)] {
+			continue
+		}
+		result = append(result, addedField(field))
+		changed = true
+	}
+	return result, changed
+}
+
+// addedField returns field copy with mode allowed for a column added to existing table
+func addedField(field *bigquery.TableFieldSchema) *bigquery.TableFieldSchema {
+	result := *field
+	if result.Mode != modeRepeated {
+		result.Mode = modeNullable
+	}
+	if len(field.Fields) > 0 {
+		result.Fields = make([]*bigquery.TableFieldSchema, len(field.Fields))
+		for i, nested := range field.Fields {
+			result.Fields[i] = addedField(nested)
+		}
+	}
+	return &result
+}
+
+func isRecord(fieldType string) bool {
+	return strings.EqualFold(fieldType, string(FieldTypeRecord)) || strings.EqualFold(fieldType, "STRUCT")
+}
@@ -0,0 +1,100 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/bigquery/v2"
+)
+
+func TestMerge(t *testing.T) {
+	existing := []*bigquery.TableFieldSchema{
+		{Name: "id", Type: "INTEGER", Mode: "REQUIRED"},
+		{Name: "name", Type: "STRING", Mode: "REQUIRED"},
+		{Name: "address", Type: "RECORD", Mode: "NULLABLE", Fields: []*bigquery.TableFieldSchema{
+			{Name: "city", Type: "STRING", Mode: "NULLABLE"},
+		}},
+	}
+	desired := []*bigquery.TableFieldSchema{
+		{Name: "ID", Type: "INTEGER", Mode: "REQUIRED"},
+		{Name: "name", Type: "STRING", Mode: "NULLABLE"},
+		{Name: "address", Type: "RECORD", Mode: "NULLABLE", Fields: []*bigquery.TableFieldSchema{
+			{Name: "city", Type: "STRING", Mode: "NULLABLE"},
+			{Name: "zip", Type: "STRING", Mode: "REQUIRED"},
+		}},
+		{Name: "tags", Type: "STRING", Mode: "REPEATED"},
+		{Name: "score", Type: "FLOAT", Mode: "REQUIRED"},
+	}
+	var testCases = []struct {
+		description   string
+		desired       []*bigquery.TableFieldSchema
+		options       MergeOptions
+		expectChanged bool
+		expect        []*bigquery.TableFieldSchema
+	}{
+		{
+			description: "no options",
+			desired:     desired,
+			expect:      existing,
+		},
+		{
+			description:   "addition",
+			desired:       desired,
+			options:       MergeOptions{AllowAddition: true},
+			expectChanged: true,
+			expect: []*bigquery.TableFieldSchema{
+				{Name: "id", Type: "INTEGER", Mode: "REQUIRED"},
+				{Name: "name", Type: "STRING", Mode: "REQUIRED"},
+				{Name: "address", Type: "RECORD", Mode: "NULLABLE", Fields: []*bigquery.TableFieldSchema{
+					{Name: "city", Type: "STRING", Mode: "NULLABLE"},
+					{Name: "zip", Type: "STRING", Mode: "NULLABLE"},
+				}},
+				{Name: "tags", Type: "STRING", Mode: "REPEATED"},
+				{Name: "score", Type: "FLOAT", Mode: "NULLABLE"},
+			},
+		},
+		{
+			description:   "relaxation",
+			desired:       desired,
+			options:       MergeOptions{AllowRelaxation: true},
+			expectChanged: true,
+			expect: []*bigquery.TableFieldSchema{
+				{Name: "id", Type: "INTEGER", Mode: "REQUIRED"},
+				{Name: "name", Type: "STRING", Mode: "NULLABLE"},
+				existing[2],
+			},
+		},
+		{
+			description: "unchanged",
+			desired:     existing,
+			options:     MergeOptions{AllowAddition: true, AllowRelaxation: true},
+			expect:      existing,
+		},
+	}
+	for _, testCase := range testCases {
+		actual, changed := Merge(existing, testCase.desired, testCase.options)
+		assert.Equal(t, testCase.expectChanged, changed, testCase.description)
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+	assert.Equal(t, "REQUIRED", existing[1].Mode, "existing fields are not modified")
+}
+
+func TestFieldsOfJSON(t *testing.T) {
+	records := []map[string]bigquery.JsonValue{
+		{"id": float64(1), "name": "a", "tags": []interface{}{"x"}, "address": map[string]interface{}{"city": "Austin"}},
+		{"id": float64(2), "name": nil, "score": 1.5, "address": map[string]interface{}{"city": "Dallas", "zip": "75001"}},
+		{"id": float64(3), "score": float64(2), "active": true},
+	}
+	expect := []*bigquery.TableFieldSchema{
+		{Name: "address", Type: "RECORD", Mode: "NULLABLE", Fields: []*bigquery.TableFieldSchema{
+			{Name: "city", Type: "STRING", Mode: "NULLABLE"},
+			{Name: "zip", Type: "STRING", Mode: "NULLABLE"},
+		}},
+		{Name: "id", Type: "INTEGER", Mode: "NULLABLE"},
+		{Name: "name", Type: "STRING", Mode: "NULLABLE"},
+		{Name: "tags", Type: "STRING", Mode: "REPEATED"},
+		{Name: "score", Type: "FLOAT", Mode: "NULLABLE"},
+		{Name: "active", Type: "BOOLEAN", Mode: "NULLABLE"},
+	}
+	assert.Equal(t, expect, FieldsOfJSON(records))
+}
@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+)
+
+// TagName represents struct field tag name
+const TagName = "bigquery"
+
+// Tag represents `bigquery:"name[,nullable|required][,type=<TYPE>][,insertID]"` struct field tag
+type Tag struct {
+	Name     string
+	Type     string
+	Nullable bool
+	Required bool
+	InsertID bool
+	Skip     bool
+}
+
+// ParseTag parses struct field tag, json tag name is used when bigquery tag is not defined
+func ParseTag(field reflect.StructField) *Tag {
+	result := &Tag{Name: field.Name}
+	value, ok := field.Tag.Lookup(TagName)
+	if !ok {
+		if value, ok = field.Tag.Lookup("json"); ok {
+			if index := strings.Index(value, ","); index != -1 {
+				value = value[:index]
+			}
+		}
+	}
+	if value == "-" {
+		result.Skip = true
+		return result
+	}
+	elements := strings.Split(value, ",")
+	if name := strings.TrimSpace(elements[0]); name != "" {
+		result.Name = name
+	}
+	for _, element := range elements[1:] {
+		element = strings.TrimSpace(element)
+		switch {
+		case strings.EqualFold(element, "nullable"):
+			result.Nullable = true
+		case strings.EqualFold(element, "required"):
+			result.Required = true
+		case strings.EqualFold(element, "insertID"):
+			result.InsertID = true
+		case strings.HasPrefix(strings.ToLower(element), "type="):
+			result.Type = strings.ToUpper(element[5:])
+		}
+	}
+	return result
+}
@@ -0,0 +1,124 @@
+package schema
+
+import (
+	"fmt"
+	"google.golang.org/api/bigquery/v2"
+	"strings"
+	"unicode"
+)
+
+// TypeName returns field database type name, REPEATED fields use ARRAY<T> and RECORD fields STRUCT<name T, ...>
+func TypeName(field *bigquery.TableFieldSchema) string {
+	name := field.Type
+	if len(field.Fields) > 0 {
+		var fields = make([]string, len(field.Fields))
+		for i, subField := range field.Fields {
+			fields[i] = subField.Name + " " + TypeName(subField)
+		}
+		name = "STRUCT<" + strings.Join(fields, ", ") + ">"
+	}
+	if field.Mode == "REPEATED" {
+		return "ARRAY<" + name + ">"
+	}
+	return name
+}
+
+// ParseTypeName parses database type name produced by TypeName into field schema without name
+func ParseTypeName(typeName string) (*bigquery.TableFieldSchema, error) {
+	parser := &typeNameParser{text: typeName}
+	result, err := parser.parseType()
+	if err != nil {
+		return nil, fmt.Errorf("invalid type name: %v, %w", typeName, err)
+	}
+	if parser.skipSpaces(); parser.pos < len(parser.text) {
+		return nil, fmt.Errorf("invalid type name: %v, unexpected: %q at %v", typeName, parser.text[parser.pos:], parser.pos)
+	}
+	return result, nil
+}
+
+type typeNameParser struct {
+	text string
+	pos  int
+}
+
+func (p *typeNameParser) parseType() (*bigquery.TableFieldSchema, error) {
+	name, err := p.identifier()
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToUpper(name) {
+	case "ARRAY":
+		if err = p.expect('<'); err != nil {
+			return nil, err
+		}
+		result, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		result.Mode = "REPEATED"
+		return result, p.expect('>')
+	case "STRUCT", "RECORD":
+		if err = p.expect('<'); err != nil {
+			return nil, err
+		}
+		result := &bigquery.TableFieldSchema{Type: string(FieldTypeRecord)}
+		for {
+			fieldName, err := p.identifier()
+			if err != nil {
+				return nil, err
+			}
+			field, err := p.parseType()
+			if err != nil {
+				return nil, err
+			}
+			field.Name = fieldName
+			result.Fields = append(result.Fields, field)
+			if p.skipSpaces(); p.pos < len(p.text) && p.text[p.pos] == ',' {
+				p.pos++
+				continue
+			}
+			return result, p.expect('>')
+		}
+	}
+	p.skipParameters()
+	return &bigquery.TableFieldSchema{Type: strings.ToUpper(name)}, nil
+}
+
+// skipParameters skips parameterized type arguments, i.e. NUMERIC(10, 2) or STRING(20)
+func (p *typeNameParser) skipParameters() {
+	if p.skipSpaces(); p.pos < len(p.text) && p.text[p.pos] == '(' {
+		if index := strings.IndexByte(p.text[p.pos:], ')'); index != -1 {
+			p.pos += index + 1
+		}
+	}
+}
+
+func (p *typeNameParser) identifier() (string, error) {
+	p.skipSpaces()
+	begin := p.pos
+	for p.pos < len(p.text) {
+		r := rune(p.text[p.pos])
+		if !(r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			break
+		}
+		p.pos++
+	}
+	if begin == p.pos {
+		return "", fmt.Errorf("expected identifier at %v", begin)
+	}
+	return p.text[begin:p.pos], nil
+}
+
+func (p *typeNameParser) expect(c byte) error {
+	if p.skipSpaces(); p.pos < len(p.text) && p.text[p.pos] == c {
+		p.pos++
+		return nil
+	}
+	return fmt.Errorf("expected %q at %v", c, p.pos)
+}
+
+func (p *typeNameParser) skipSpaces() {
+	for p.pos < len(p.text) && p.text[p.pos] == ' ' {
+		p.pos++
+	}
+}
@@ -0,0 +1,92 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/bigquery/v2"
+)
+
+func TestTypeName(t *testing.T) {
+	var testCases = []struct {
+		description string
+		field       *bigquery.TableFieldSchema
+		expect      string
+	}{
+		{
+			description: "basic",
+			field:       &bigquery.TableFieldSchema{Name: "id", Type: "INTEGER", Mode: "REQUIRED"},
+			expect:      "INTEGER",
+		},
+		{
+			description: "repeated",
+			field:       &bigquery.TableFieldSchema{Name: "tags", Type: "STRING", Mode: "REPEATED"},
+			expect:      "ARRAY<STRING>",
+		},
+		{
+			description: "nested repeated record",
+			field: &bigquery.TableFieldSchema{Name: "addresses", Type: "RECORD", Mode: "REPEATED", Fields: []*bigquery.TableFieldSchema{
+				{Name: "city", Type: "STRING", Mode: "NULLABLE"},
+				{Name: "geo", Type: "RECORD", Mode: "REQUIRED", Fields: []*bigquery.TableFieldSchema{
+					{Name: "lat", Type: "FLOAT", Mode: "NULLABLE"},
+				}},
+				{Name: "zips", Type: "INTEGER", Mode: "REPEATED"},
+			}},
+			expect: "ARRAY<STRUCT<city STRING, geo STRUCT<lat FLOAT>, zips ARRAY<INTEGER>>>",
+		},
+	}
+	for _, testCase := range testCases {
+		actual := TypeName(testCase.field)
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+		parsed, err := ParseTypeName(actual)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		parsed.Name = testCase.field.Name
+		assert.Equal(t, actual, TypeName(parsed), testCase.description)
+	}
+}
+
+func TestParseTypeName(t *testing.T) {
+	var testCases = []struct {
+		description string
+		typeName    string
+		expect      *bigquery.TableFieldSchema
+		expectErr   bool
+	}{
+		{
+			description: "parameterized",
+			typeName:    "NUMERIC(10, 2)",
+			expect:      &bigquery.TableFieldSchema{Type: "NUMERIC"},
+		},
+		{
+			description: "struct",
+			typeName:    "STRUCT<city STRING, tags ARRAY<STRING>>",
+			expect: &bigquery.TableFieldSchema{Type: "RECORD", Fields: []*bigquery.TableFieldSchema{
+				{Name: "city", Type: "STRING"},
+				{Name: "tags", Type: "STRING", Mode: "REPEATED"},
+			}},
+		},
+		{
+			description: "unterminated",
+			typeName:    "ARRAY<STRING",
+			expectErr:   true,
+		},
+		{
+			description: "trailing text",
+			typeName:    "STRING>",
+			expectErr:   true,
+		},
+	}
+	for _, testCase := range testCases {
+		actual, err := ParseTypeName(testCase.typeName)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
@@ -0,0 +1,23 @@
+package sqlscan
+
+import (
+	smatcher "github.com/viant/bigquery/internal/ingestion/matcher"
+	"github.com/viant/parsly"
+)
+
+const (
+	Text = iota
+	StringLiteral
+	NumericLiteral
+	QuotedIdentifier
+	Comment
+	Hint
+)
+
+var stringLiteralMatcher = parsly.NewToken(StringLiteral, "'string'", smatcher.NewStringLiteral())
+var numericLiteralMatcher = parsly.NewToken(NumericLiteral, "number", smatcher.NewNumber())
+var quotedIdentifierMatcher = parsly.NewToken(QuotedIdentifier, "`identifier`", smatcher.NewBlock("`", "`", true))
+var hintMatcher = parsly.NewToken(Hint, "/*+ HINT +*/", smatcher.NewBlock("/*+", "+*/", false))
+var blockCommentMatcher = parsly.NewToken(Comment, "/* comment */", smatcher.NewBlock("/*", "*/", true))
+var dashCommentMatcher = parsly.NewToken(Comment, "-- comment", smatcher.NewBlock("--", "\n", true))
+var hashCommentMatcher = parsly.NewToken(Comment, "# comment", smatcher.NewBlock("#", "\n", true))
@@ -0,0 +1,36 @@
+// Package sqlscan splits SQL into literals, quoted identifiers, comments, hints and other text,
+// it is shared by hint extraction and SQL literal redaction
+package sqlscan
+
+import "github.com/viant/parsly"
+
+// Fragment represents SQL fragment of the token code
+type Fragment struct {
+	Code  int
+	Begin int
+	End   int
+}
+
+// Scan returns SQL fragments, bytes outside literals, quoted identifiers, comments and hints are merged into Text fragments
+func Scan(SQL string) []*Fragment {
+	var result []*Fragment
+	cursor := parsly.NewCursor("", []byte(SQL), 0)
+	text := 0
+	for cursor.HasMore() {
+		pos := cursor.Pos
+		match := cursor.MatchAny(stringLiteralMatcher, quotedIdentifierMatcher, hintMatcher, blockCommentMatcher, dashCommentMatcher, hashCommentMatcher, numericLiteralMatcher)
+		if match.Code == parsly.Invalid {
+			cursor.Pos++
+			continue
+		}
+		if text < pos {
+			result = append(result, &Fragment{Code: Text, Begin: text, End: pos})
+		}
+		result = append(result, &Fragment{Code: match.Code, Begin: pos, End: cursor.Pos})
+		text = cursor.Pos
+	}
+	if text < len(SQL) {
+		result = append(result, &Fragment{Code: Text, Begin: text, End: len(SQL)})
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package sqlscan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScan(t *testing.T) {
+	var testCases = []struct {
+		description string
+		SQL         string
+		expect      []string
+		codes       []int
+	}{
+		{
+			description: "literals and identifiers",
+			SQL:         "SELECT c1, 'a''b', 1.5e-3 FROM `p.d.t`",
+			expect:      []string{"SELECT c1, ", "'a'", "'b'", ", ", "1.5e-3", " FROM ", "`p.d.t`"},
+			codes:       []int{Text, StringLiteral, StringLiteral, Text, NumericLiteral, Text, QuotedIdentifier},
+		},
+		{
+			description: "triple quoted and escaped strings",
+			SQL:         `SELECT """a"b""", "c\"d"`,
+			expect:      []string{"SELECT ", `"""a"b"""`, ", ", `"c\"d"`},
+			codes:       []int{Text, StringLiteral, Text, StringLiteral},
+		},
+		{
+			description: "comments and hints",
+			SQL:         "SELECT /*+ a='1' +*/ 1 -- '2'\n/* 3 */# 4",
+			expect:      []string{"SELECT ", "/*+ a='1' +*/", " ", "1", " ", "-- '2'\n", "/* 3 */", "# 4"},
+			codes:       []int{Text, Hint, Text, NumericLiteral, Text, Comment, Comment, Comment},
+		},
+		{
+			description: "unterminated hint is a comment",
+			SQL:         "SELECT 1 /*+ a=1",
+			expect:      []string{"SELECT ", "1", " ", "/*+ a=1"},
+			codes:       []int{Text, NumericLiteral, Text, Comment},
+		},
+		{
+			description: "unterminated string",
+			SQL:         "SELECT 'abc",
+			expect:      []string{"SELECT ", "'abc"},
+			codes:       []int{Text, StringLiteral},
+		},
+	}
+
+	for _, testCase := range testCases {
+		var actual []string
+		var codes []int
+		for _, fragment := range Scan(testCase.SQL) {
+			actual = append(actual, testCase.SQL[fragment.Begin:fragment.End])
+			codes = append(codes, fragment.Code)
+		}
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+		assert.Equal(t, testCase.codes, codes, testCase.description)
+	}
+}
@@ -0,0 +1,83 @@
+// Package trace provides OpenTelemetry instrumentation of the driver job lifecycle.
+//
+// Spans are created with the global tracer provider, thus instrumentation is a no-op
+// unless the application registers a provider with otel.SetTracerProvider.
+package trace
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"google.golang.org/api/bigquery/v2"
+)
+
+// InstrumentationName is the driver tracer name
+const InstrumentationName = "github.com/viant/bigquery"
+
+// Attribute keys
+const (
+	SystemKey         = attribute.Key("db.system")
+	ProjectKey        = attribute.Key("bigquery.project")
+	LocationKey       = attribute.Key("bigquery.location")
+	JobIDKey          = attribute.Key("bigquery.job.id")
+	JobStateKey       = attribute.Key("bigquery.job.state")
+	JobKindKey        = attribute.Key("bigquery.job.kind")
+	JobPollsKey       = attribute.Key("bigquery.job.polls")
+	BytesProcessedKey = attribute.Key("bigquery.bytes_processed")
+	BytesBilledKey    = attribute.Key("bigquery.bytes_billed")
+	CacheHitKey       = attribute.Key("bigquery.cache_hit")
+	RowsKey           = attribute.Key("bigquery.rows")
+	TotalRowsKey      = attribute.Key("bigquery.total_rows")
+	AffectedRowsKey   = attribute.Key("bigquery.affected_rows")
+	RetryAttemptsKey  = attribute.Key("bigquery.retry.attempts")
+	RetryReasonKey    = attribute.Key("bigquery.retry.reason")
+)
+
+// Start starts a client span, parent span is taken from ctx
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, oteltrace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	attributes = append(attributes, SystemKey.String("bigquery"))
+	return otel.Tracer(InstrumentationName).Start(ctx, name, oteltrace.WithSpanKind(oteltrace.SpanKindClient), oteltrace.WithAttributes(attributes...))
+}
+
+// End records error if any and ends span
+func End(span oteltrace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// SetJob sets job reference, state and statistics attributes
+func SetJob(span oteltrace.Span, job *bigquery.Job) {
+	if job == nil || !span.IsRecording() {
+		return
+	}
+	if ref := job.JobReference; ref != nil {
+		span.SetAttributes(ProjectKey.String(ref.ProjectId), JobIDKey.String(ref.JobId), LocationKey.String(ref.Location))
+	}
+	if job.Status != nil {
+		span.SetAttributes(JobStateKey.String(job.Status.State))
+	}
+	stats := job.Statistics
+	if stats == nil {
+		return
+	}
+	if query := stats.Query; query != nil {
+		span.SetAttributes(
+			BytesProcessedKey.Int64(query.TotalBytesProcessed),
+			BytesBilledKey.Int64(query.TotalBytesBilled),
+			CacheHitKey.Bool(query.CacheHit),
+			AffectedRowsKey.Int64(query.NumDmlAffectedRows))
+		return
+	}
+	if load := stats.Load; load != nil {
+		span.SetAttributes(AffectedRowsKey.Int64(load.OutputRows))
+	}
+	span.SetAttributes(BytesProcessedKey.Int64(stats.TotalBytesProcessed))
+}
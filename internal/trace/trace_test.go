@@ -0,0 +1,70 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/api/bigquery/v2"
+)
+
+func TestSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	var testCases = []struct {
+		description  string
+		job          *bigquery.Job
+		err          error
+		expectStatus codes.Code
+		expect       map[attribute.Key]attribute.Value
+	}{
+		{
+			description:  "query job",
+			job:          &bigquery.Job{JobReference: &bigquery.JobReference{ProjectId: "p", JobId: "j1", Location: "US"}, Status: &bigquery.JobStatus{State: "DONE"}, Statistics: &bigquery.JobStatistics{Query: &bigquery.JobStatistics2{TotalBytesProcessed: 10, CacheHit: true}}},
+			expectStatus: codes.Unset,
+			expect: map[attribute.Key]attribute.Value{
+				SystemKey:         attribute.StringValue("bigquery"),
+				JobIDKey:          attribute.StringValue("j1"),
+				LocationKey:       attribute.StringValue("US"),
+				JobStateKey:       attribute.StringValue("DONE"),
+				BytesProcessedKey: attribute.Int64Value(10),
+				CacheHitKey:       attribute.BoolValue(true),
+			},
+		},
+		{
+			description:  "load job with error",
+			job:          &bigquery.Job{Statistics: &bigquery.JobStatistics{Load: &bigquery.JobStatistics3{OutputRows: 3}}},
+			err:          fmt.Errorf("failed"),
+			expectStatus: codes.Error,
+			expect: map[attribute.Key]attribute.Value{
+				AffectedRowsKey: attribute.Int64Value(3),
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		_, span := Start(context.Background(), testCase.description)
+		SetJob(span, testCase.job)
+		End(span, testCase.err)
+		spans := recorder.Ended()
+		actual := spans[len(spans)-1]
+		assert.Equal(t, testCase.description, actual.Name(), testCase.description)
+		assert.Equal(t, testCase.expectStatus, actual.Status().Code, testCase.description)
+		var attributes = map[attribute.Key]attribute.Value{}
+		for _, kv := range actual.Attributes() {
+			attributes[kv.Key] = kv.Value
+		}
+		for key, expect := range testCase.expect {
+			assert.Equal(t, expect, attributes[key], testCase.description+" "+string(key))
+		}
+	}
+}
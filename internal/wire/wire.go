@@ -0,0 +1,74 @@
+// Package wire defines BigQuery JSON wire format of temporal, numeric and float values shared by load and export encoders
+package wire
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TimestampLayout = "2006-01-02T15:04:05.999999Z07:00"
+	DateLayout      = "2006-01-02"
+	DatetimeLayout  = "2006-01-02T15:04:05.999999"
+	TimeLayout      = "15:04:05.999999"
+	NumericScale    = 9
+	BigNumericScale = 38
+)
+
+// TimeFormatter returns time formatter of the data type, TIMESTAMP values are formatted in UTC by default
+func TimeFormatter(dataType string) func(t time.Time) string {
+	switch ElementType(dataType) {
+	case "DATE":
+		return func(t time.Time) string { return t.Format(DateLayout) }
+	case "DATETIME":
+		return func(t time.Time) string { return t.Format(DatetimeLayout) }
+	case "TIME":
+		return func(t time.Time) string { return t.Format(TimeLayout) }
+	}
+	return func(t time.Time) string { return t.UTC().Format(TimestampLayout) }
+}
+
+// Scale returns decimal scale of NUMERIC or BIGNUMERIC data type
+func Scale(dataType string) int {
+	if ElementType(dataType) == "BIGNUMERIC" {
+		return BigNumericScale
+	}
+	return NumericScale
+}
+
+// ElementType returns upper case data type, ARRAY<T> returns T
+func ElementType(dataType string) string {
+	dataType = strings.ToUpper(dataType)
+	if strings.HasPrefix(dataType, "ARRAY<") && strings.HasSuffix(dataType, ">") {
+		return dataType[len("ARRAY<") : len(dataType)-1]
+	}
+	return dataType
+}
+
+// FormatFloat formats float, NaN and infinities use BigQuery names
+func FormatFloat(value float64, bits int) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "Infinity"
+	case math.IsInf(value, -1):
+		return "-Infinity"
+	}
+	return strconv.FormatFloat(value, 'g', -1, bits)
+}
+
+// EncodeFloat writes float as JSON, NaN and infinities are quoted
+func EncodeFloat(buffer *bytes.Buffer, value float64, bits int) {
+	formatted := FormatFloat(value, bits)
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		buffer.WriteByte('"')
+		buffer.WriteString(formatted)
+		buffer.WriteByte('"')
+		return
+	}
+	buffer.WriteString(formatted)
+}
@@ -0,0 +1,52 @@
+package wire
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeFormatter(t *testing.T) {
+	ts := time.Date(2024, 3, 4, 5, 6, 7, 800000000, time.FixedZone("x", 3600))
+	var testCases = []struct {
+		dataType string
+		expect   string
+	}{
+		{dataType: "TIMESTAMP", expect: "2024-03-04T04:06:07.8Z"},
+		{dataType: "", expect: "2024-03-04T04:06:07.8Z"},
+		{dataType: "date", expect: "2024-03-04"},
+		{dataType: "DATETIME", expect: "2024-03-04T05:06:07.8"},
+		{dataType: "ARRAY<TIME>", expect: "05:06:07.8"},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, TimeFormatter(testCase.dataType)(ts), testCase.dataType)
+	}
+}
+
+func TestScale(t *testing.T) {
+	assert.Equal(t, NumericScale, Scale("NUMERIC"))
+	assert.Equal(t, BigNumericScale, Scale("bignumeric"))
+	assert.Equal(t, BigNumericScale, Scale("ARRAY<BIGNUMERIC>"))
+}
+
+func TestEncodeFloat(t *testing.T) {
+	var testCases = []struct {
+		value  float64
+		bits   int
+		expect string
+	}{
+		{value: 1.5, bits: 64, expect: "1.5"},
+		{value: float64(float32(0.1)), bits: 32, expect: "0.1"},
+		{value: math.NaN(), bits: 64, expect: `"NaN"`},
+		{value: math.Inf(1), bits: 64, expect: `"Infinity"`},
+		{value: math.Inf(-1), bits: 64, expect: `"-Infinity"`},
+	}
+	for _, testCase := range testCases {
+		buffer := new(bytes.Buffer)
+		EncodeFloat(buffer, testCase.value, testCase.bits)
+		assert.Equal(t, testCase.expect, buffer.String(), testCase.expect)
+	}
+}
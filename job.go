@@ -0,0 +1,210 @@
+package bigquery
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/bigquery/internal/command"
+	"github.com/viant/bigquery/internal/exec"
+	"github.com/viant/bigquery/internal/logging"
+	"google.golang.org/api/bigquery/v2"
+)
+
+// Job represents an asynchronously submitted job handle.
+// Job can be persisted with its Reference and restored with AttachJob.
+type Job struct {
+	ProjectID string
+	Location  string
+	ID        string
+	conn      *sql.Conn
+}
+
+// JobStatus represents job status
+type JobStatus struct {
+	State      string
+	Done       bool
+	Err        error
+	Statistics *bigquery.JobStatistics
+}
+
+// Reference returns job reference in project:location.jobID format
+func (j *Job) Reference() string {
+	return j.ProjectID + ":" + j.Location + "." + j.ID
+}
+
+// Status returns the current job status
+func (j *Job) Status(ctx context.Context) (*JobStatus, error) {
+	var job *bigquery.Job
+	err := withConnection(j.conn, func(c *connection) error {
+		service, err := c.contextService(ctx)
+		if err != nil {
+			return err
+		}
+		ctx := logging.WithDefault(ctx, c.logger)
+		return exec.RunWithRetries(ctx, func() error {
+			call := service.Jobs.Get(j.ProjectID, j.ID)
+			call.Location(j.Location)
+			job, err = call.Context(ctx).Do()
+			return err
+		}, 3)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job status: %v, %w", j.Reference(), err)
+	}
+	return newJobStatus(job), nil
+}
+
+// Wait waits for the job completion
+func (j *Job) Wait(ctx context.Context) (*JobStatus, error) {
+	var job *bigquery.Job
+	err := withConnection(j.conn, func(c *connection) error {
+		service, err := c.contextService(ctx)
+		if err != nil {
+			return err
+		}
+		job, err = exec.WaitForJobCompletion(logging.WithDefault(ctx, c.logger), service, j.ProjectID, j.Location, j.ID)
+		return err
+	})
+	//job own failure is reported with the status, other errors i.e. cancelled ctx are returned
+	if err != nil && (job == nil || job.Status == nil || job.Status.ErrorResult == nil) {
+		return nil, fmt.Errorf("failed to wait for job: %v, %w", j.Reference(), err)
+	}
+	return newJobStatus(job), nil
+}
+
+// Cancel requests the job cancellation
+func (j *Job) Cancel(ctx context.Context) error {
+	return withConnection(j.conn, func(c *connection) error {
+		service, err := c.contextService(ctx)
+		if err != nil {
+			return err
+		}
+		ctx := logging.WithDefault(ctx, c.logger)
+		return exec.RunWithRetries(ctx, func() error {
+			call := service.Jobs.Cancel(j.ProjectID, j.ID)
+			call.Location(j.Location)
+			_, err := call.Context(ctx).Do()
+			return err
+		}, 3)
+	})
+}
+
+// Rows waits for the job completion and returns its rows
+func (j *Job) Rows(ctx context.Context) (*sql.Rows, error) {
+	return ResultsOf(ctx, j.conn, j.Reference())
+}
+
+// Submit submits a query job and returns its handle without waiting for the job completion.
+// The connection has to stay open as long as the returned job handle is used.
+func Submit(ctx context.Context, conn *sql.Conn, SQL string, args ...interface{}) (*Job, error) {
+	var result *Job
+	err := withConnection(conn, func(c *connection) error {
+		stmt, err := c.prepareQueryJob(ctx, SQL, args)
+		if err != nil {
+			return err
+		}
+		job, err := stmt.submitJob(ctx)
+		if err != nil {
+			return fmt.Errorf("%w, SQL: %v", err, SQL)
+		}
+		ref := job.JobReference
+		result = &Job{ProjectID: ref.ProjectId, Location: ref.Location, ID: ref.JobId, conn: conn}
+		return nil
+	})
+	return result, err
+}
+
+// DryRun validates a query without running it, returned statistics include processed bytes estimate,
+// referenced tables and result schema.
+func DryRun(ctx context.Context, conn *sql.Conn, SQL string, args ...interface{}) (*JobStatus, error) {
+	var result *JobStatus
+	err := withConnection(conn, func(c *connection) error {
+		stmt, err := c.prepareQueryJob(ctx, SQL, args)
+		if err != nil {
+			return err
+		}
+		stmt.job.Configuration.DryRun = true
+		job, err := stmt.submitJob(ctx)
+		if err != nil {
+			return fmt.Errorf("%w, SQL: %v", err, SQL)
+		}
+		result = newJobStatus(job)
+		return nil
+	})
+	return result, err
+}
+
+// prepareQueryJob prepares query statement with bound query parameters
+func (c *connection) prepareQueryJob(ctx context.Context, SQL string, args []interface{}) (*Statement, error) {
+	prepared, err := c.PrepareContext(ctx, SQL)
+	if err != nil {
+		return nil, err
+	}
+	stmt, ok := prepared.(*Statement)
+	if !ok {
+		return nil, fmt.Errorf("unsupported query job statement: %v", SQL)
+	}
+	if stmt.job.Configuration.Query.QueryParameters, err = namedValues(args).QueryParameter(); err != nil {
+		return nil, fmt.Errorf("failed to convert args to query parameters: %w", err)
+	}
+	return stmt, nil
+}
+
+// AttachJob returns handle of an existing job, reference uses [project:][location.]jobID format,
+// unspecified project and location default to the connection ones.
+func AttachJob(conn *sql.Conn, reference string) (*Job, error) {
+	ref, err := command.ParseJob(reference)
+	if err != nil {
+		return nil, err
+	}
+	err = withConnection(conn, func(c *connection) error {
+		ref.Init(c.projectID, c.cfg.Location)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Job{ProjectID: ref.ProjectID, Location: ref.Location, ID: ref.ID, conn: conn}, nil
+}
+
+func newJobStatus(job *bigquery.Job) *JobStatus {
+	result := &JobStatus{Statistics: job.Statistics}
+	if status := job.Status; status != nil {
+		result.State = status.State
+		result.Done = status.State == exec.StatusDone
+		if status.ErrorResult != nil {
+			errors, _ := json.Marshal(status.Errors)
+			result.Err = fmt.Errorf("%v: %s", status.ErrorResult.Message, errors)
+		}
+	}
+	return result
+}
+
+// withConnection runs fn with the driver connection
+func withConnection(conn *sql.Conn, fn func(c *connection) error) error {
+	if conn == nil {
+		return fmt.Errorf("conn was nil")
+	}
+	return conn.Raw(func(driverConn interface{}) error {
+		c, ok := driverConn.(*connection)
+		if !ok {
+			return fmt.Errorf("unsupported driver connection: %T", driverConn)
+		}
+		return fn(c)
+	})
+}
+
+// namedValues converts args to named values
+func namedValues(args []interface{}) NamedValues {
+	var result = make(NamedValues, len(args))
+	for i, arg := range args {
+		result[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+		if named, ok := arg.(sql.NamedArg); ok {
+			result[i].Name = named.Name
+			result[i].Value = named.Value
+		}
+	}
+	return result
+}
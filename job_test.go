@@ -0,0 +1,108 @@
+package bigquery
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+)
+
+func TestJob_Reference(t *testing.T) {
+	job := &Job{ProjectID: "my-project", Location: "us", ID: "job_123"}
+	assert.Equal(t, "my-project:us.job_123", job.Reference())
+}
+
+func TestNewJobStatus(t *testing.T) {
+	var testCases = []struct {
+		description string
+		job         *bigquery.Job
+		expectDone  bool
+		expectErr   bool
+	}{
+		{
+			description: "running job",
+			job:         &bigquery.Job{Status: &bigquery.JobStatus{State: "RUNNING"}},
+		},
+		{
+			description: "done job",
+			job:         &bigquery.Job{Status: &bigquery.JobStatus{State: "DONE"}, Statistics: &bigquery.JobStatistics{TotalBytesProcessed: 10}},
+			expectDone:  true,
+		},
+		{
+			description: "failed job",
+			job:         &bigquery.Job{Status: &bigquery.JobStatus{State: "DONE", ErrorResult: &bigquery.ErrorProto{Message: "syntax error"}}},
+			expectDone:  true,
+			expectErr:   true,
+		},
+	}
+	for _, testCase := range testCases {
+		status := newJobStatus(testCase.job)
+		assert.Equal(t, testCase.job.Status.State, status.State, testCase.description)
+		assert.Equal(t, testCase.expectDone, status.Done, testCase.description)
+		assert.Equal(t, testCase.expectErr, status.Err != nil, testCase.description)
+		assert.Equal(t, testCase.job.Statistics, status.Statistics, testCase.description)
+	}
+}
+
+func TestNamedValues(t *testing.T) {
+	values := namedValues([]interface{}{1, sql.Named("name", "abc")})
+	assert.Equal(t, 1, values[0].Ordinal)
+	assert.Equal(t, "", values[0].Name)
+	assert.Equal(t, 1, values[0].Value)
+	assert.Equal(t, "name", values[1].Name)
+	assert.Equal(t, "abc", values[1].Value)
+}
+
+func TestJob_Wait(t *testing.T) {
+	var testCases = []struct {
+		description  string
+		response     string
+		timeout      time.Duration
+		expectErr    error
+		expectState  string
+		expectJobErr bool
+	}{
+		{description: "done", response: `{"status": {"state": "DONE"}}`, expectState: "DONE"},
+		{description: "job failure reported with status", response: `{"status": {"state": "DONE", "errorResult": {"message": "syntax error"}}}`, expectState: "DONE", expectJobErr: true},
+		{description: "cancelled wait returns error", response: `{"status": {"state": "RUNNING"}}`, timeout: 200 * time.Millisecond, expectErr: context.DeadlineExceeded},
+	}
+	for _, testCase := range testCases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(testCase.response))
+		}))
+		db := sql.OpenDB(&connector{
+			cfg:     &Config{ProjectID: "p"},
+			options: []option.ClientOption{option.WithHTTPClient(server.Client()), option.WithEndpoint(server.URL)},
+		})
+		ctx := context.Background()
+		conn, err := db.Conn(ctx)
+		if !assert.NoError(t, err, testCase.description) {
+			server.Close()
+			continue
+		}
+		if testCase.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, testCase.timeout)
+			defer cancel()
+		}
+		job := &Job{ProjectID: "p", Location: "us", ID: "j1", conn: conn}
+		status, err := job.Wait(ctx)
+		if testCase.expectErr != nil {
+			assert.ErrorIs(t, err, testCase.expectErr, testCase.description)
+			assert.Nil(t, status, testCase.description)
+		} else if assert.NoError(t, err, testCase.description) {
+			assert.Equal(t, testCase.expectState, status.State, testCase.description)
+			assert.Equal(t, testCase.expectJobErr, status.Err != nil, testCase.description)
+		}
+		_ = conn.Close()
+		_ = db.Close()
+		server.Close()
+	}
+}
@@ -0,0 +1,42 @@
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/viant/bigquery/internal/label"
+)
+
+const appLabel = "app"
+
+// WithLabels returns a context carrying job labels, labels are merged into every query and load job
+// submitted with the returned context, context labels take precedence over DSN and hint labels.
+func WithLabels(ctx context.Context, labels map[string]string) context.Context {
+	return label.NewContext(ctx, labels)
+}
+
+// parseLabels parses labels in k1:v1,k2:v2 format
+func parseLabels(text string) (map[string]string, error) {
+	var result = map[string]string{}
+	for _, pair := range strings.Split(text, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		index := strings.Index(pair, ":")
+		if index == -1 {
+			return nil, fmt.Errorf("invalid label: %v, expected key:value", pair)
+		}
+		result[strings.TrimSpace(pair[:index])] = strings.TrimSpace(pair[index+1:])
+	}
+	return result, nil
+}
+
+// jobLabels returns default job labels, App is sanitized into a valid label value, i.e. MyService/1.2 -> myservice_1_2
+func (c *Config) jobLabels() map[string]string {
+	var defaults map[string]string
+	if c.App != "" {
+		defaults = map[string]string{appLabel: label.SanitizeValue(c.App)}
+	}
+	return label.Merge(defaults, c.Labels)
+}
@@ -0,0 +1,45 @@
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/viant/bigquery/internal/logging"
+)
+
+// LogRedaction controls which parts of the logged SQL are redacted
+type LogRedaction = logging.Redaction
+
+const (
+	// RedactLiterals replaces string and numeric SQL literals with ?
+	RedactLiterals = logging.RedactLiterals
+	// RedactParameters logs query parameter names and types without values
+	RedactParameters = logging.RedactParameters
+)
+
+// WithLogger returns a context whose driver calls are logged with logger instead of the connector one,
+// i.e. to trace a single request verbosely, the connector redaction still applies.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return logging.NewContext(ctx, logger)
+}
+
+// parseRedaction parses redaction in literals,params format
+func parseRedaction(text string) (LogRedaction, error) {
+	var result LogRedaction
+	for _, item := range strings.Split(text, ",") {
+		switch strings.ToLower(strings.TrimSpace(item)) {
+		case "":
+		case "literals":
+			result |= RedactLiterals
+		case "params", "parameters":
+			result |= RedactParameters
+		case "all":
+			result |= RedactLiterals | RedactParameters
+		default:
+			return 0, fmt.Errorf("invalid redaction: %v, supported: [literals|params|all]", item)
+		}
+	}
+	return result, nil
+}
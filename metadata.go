@@ -0,0 +1,296 @@
+package bigquery
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/bigquery/internal/exec"
+	"github.com/viant/bigquery/internal/ingestion"
+	"github.com/viant/bigquery/internal/logging"
+	"github.com/viant/bigquery/internal/schema"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/googleapi"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// Dataset represents dataset metadata
+type Dataset struct {
+	ProjectID    string
+	ID           string
+	Location     string
+	FriendlyName string
+	Labels       map[string]string
+}
+
+// Table represents table metadata, ListTables does not populate Schema, Columns, Description and size fields
+type Table struct {
+	ProjectID         string
+	DatasetID         string
+	ID                string
+	Type              string
+	Description       string
+	Location          string
+	Schema            *bigquery.TableSchema
+	Columns           []*Column
+	TimePartitioning  *bigquery.TimePartitioning
+	RangePartitioning *bigquery.RangePartitioning
+	Clustering        *bigquery.Clustering
+	NumRows           uint64
+	NumBytes          int64
+	Labels            map[string]string
+	Created           time.Time
+	Modified          time.Time
+	Expiration        *time.Time
+}
+
+// Column represents table column with Go scan type matching driver rows
+type Column struct {
+	Name        string
+	Type        string
+	Mode        string
+	Description string
+	ScanType    reflect.Type
+	Schema      *bigquery.TableFieldSchema
+}
+
+// Nullable returns true if column is NULLABLE
+func (c *Column) Nullable() bool {
+	return c.Mode == "" || c.Mode == "NULLABLE"
+}
+
+// ListDatasets returns datasets of the project, empty projectID uses the connection project
+func ListDatasets(ctx context.Context, conn *sql.Conn, projectID string) ([]*Dataset, error) {
+	var result []*Dataset
+	err := withConnection(conn, func(c *connection) error {
+		var err error
+		result, err = c.listDatasets(ctx, projectID)
+		return err
+	})
+	return result, err
+}
+
+// ListTables returns tables of [project.]dataset, empty dataset uses the connection dataset
+func ListTables(ctx context.Context, conn *sql.Conn, dataset string) ([]*Table, error) {
+	var result []*Table
+	err := withConnection(conn, func(c *connection) error {
+		var err error
+		result, err = c.listTables(ctx, dataset)
+		return err
+	})
+	return result, err
+}
+
+// GetTable returns [project.][dataset.]table metadata, unspecified project and dataset default to the connection ones
+func GetTable(ctx context.Context, conn *sql.Conn, table string) (*Table, error) {
+	var result *Table
+	err := withConnection(conn, func(c *connection) error {
+		var err error
+		result, err = c.getTable(ctx, table)
+		return err
+	})
+	return result, err
+}
+
+// GetSchema returns [project.][dataset.]table columns
+func GetSchema(ctx context.Context, conn *sql.Conn, table string) ([]*Column, error) {
+	aTable, err := GetTable(ctx, conn, table)
+	if err != nil {
+		return nil, err
+	}
+	return aTable.Columns, nil
+}
+
+// TableExists returns true if [project.][dataset.]table exists
+func TableExists(ctx context.Context, conn *sql.Conn, table string) (bool, error) {
+	var result bool
+	err := withConnection(conn, func(c *connection) error {
+		_, err := c.fetchTable(ctx, table, "tableReference")
+		if err == nil {
+			result = true
+			return nil
+		}
+		if exec.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+	return result, err
+}
+
+func (c *connection) listDatasets(ctx context.Context, projectID string) ([]*Dataset, error) {
+	ctx = logging.WithDefault(ctx, c.logger)
+	if projectID == "" {
+		projectID = c.projectID
+	}
+	var result []*Dataset
+	service, err := c.contextService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	call := service.Datasets.List(projectID).Context(ctx)
+	err = call.Pages(ctx, func(list *bigquery.DatasetList) error {
+		for _, item := range list.Datasets {
+			dataset := &Dataset{Location: item.Location, FriendlyName: item.FriendlyName, Labels: item.Labels}
+			if ref := item.DatasetReference; ref != nil {
+				dataset.ProjectID, dataset.ID = ref.ProjectId, ref.DatasetId
+			}
+			result = append(result, dataset)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %v datasets, %w", projectID, err)
+	}
+	return result, nil
+}
+
+func (c *connection) listTables(ctx context.Context, dataset string) ([]*Table, error) {
+	ctx = logging.WithDefault(ctx, c.logger)
+	projectID, datasetID := c.datasetReference(dataset)
+	if datasetID == "" {
+		return nil, fmt.Errorf("failed to list tables, dataset was empty")
+	}
+	var result []*Table
+	service, err := c.contextService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	call := service.Tables.List(projectID, datasetID).Context(ctx)
+	err = call.Pages(ctx, func(list *bigquery.TableList) error {
+		for _, item := range list.Tables {
+			result = append(result, newListedTable(item))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %v.%v tables, %w", projectID, datasetID, err)
+	}
+	return result, nil
+}
+
+func (c *connection) getTable(ctx context.Context, table string) (*Table, error) {
+	aTable, err := c.fetchTable(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	return newTable(aTable), nil
+}
+
+// fetchTable gets [project.][dataset.]table resource, fields optionally limit returned table fields
+func (c *connection) fetchTable(ctx context.Context, table string, fields ...googleapi.Field) (*bigquery.Table, error) {
+	ctx = logging.WithDefault(ctx, c.logger)
+	ref, err := ingestion.ParseDestination(strings.Trim(table, "`"), c.projectID, c.cfg.DatasetID)
+	if err != nil {
+		return nil, err
+	}
+	service, err := c.contextService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var aTable *bigquery.Table
+	err = exec.RunWithRetries(ctx, func() error {
+		call := service.Tables.Get(ref.ProjectId, ref.DatasetId, ref.TableId).Context(ctx)
+		if len(fields) > 0 {
+			call = call.Fields(fields...)
+		}
+		aTable, err = call.Do()
+		return err
+	}, 3)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table %v.%v.%v, %w", ref.ProjectId, ref.DatasetId, ref.TableId, err)
+	}
+	return aTable, nil
+}
+
+// datasetReference returns project and dataset of [project.]dataset, unspecified values default to the connection ones
+func (c *connection) datasetReference(dataset string) (string, string) {
+	dataset = strings.Trim(dataset, "`")
+	if dataset == "" {
+		return c.projectID, c.cfg.DatasetID
+	}
+	if index := strings.LastIndex(dataset, "."); index != -1 {
+		return dataset[:index], dataset[index+1:]
+	}
+	return c.projectID, dataset
+}
+
+func newListedTable(item *bigquery.TableListTables) *Table {
+	result := &Table{
+		Type:              item.Type,
+		Labels:            item.Labels,
+		TimePartitioning:  item.TimePartitioning,
+		RangePartitioning: item.RangePartitioning,
+		Clustering:        item.Clustering,
+		Created:           fromMillis(item.CreationTime),
+		Expiration:        optionalMillis(item.ExpirationTime),
+	}
+	if ref := item.TableReference; ref != nil {
+		result.ProjectID, result.DatasetID, result.ID = ref.ProjectId, ref.DatasetId, ref.TableId
+	}
+	return result
+}
+
+func newTable(table *bigquery.Table) *Table {
+	result := &Table{
+		Type:              table.Type,
+		Description:       table.Description,
+		Location:          table.Location,
+		Schema:            table.Schema,
+		TimePartitioning:  table.TimePartitioning,
+		RangePartitioning: table.RangePartitioning,
+		Clustering:        table.Clustering,
+		NumRows:           table.NumRows,
+		NumBytes:          table.NumBytes,
+		Labels:            table.Labels,
+		Created:           fromMillis(table.CreationTime),
+		Modified:          fromMillis(int64(table.LastModifiedTime)),
+		Expiration:        optionalMillis(table.ExpirationTime),
+	}
+	if ref := table.TableReference; ref != nil {
+		result.ProjectID, result.DatasetID, result.ID = ref.ProjectId, ref.DatasetId, ref.TableId
+	}
+	if table.Schema != nil {
+		result.Columns = newColumns(table.Schema)
+	}
+	return result
+}
+
+// newColumns converts table schema to columns, scan types are built with schema.BuildFieldType,
+// types without Go mapping (i.e. GEOGRAPHY, JSON) are scanned as interface{}
+func newColumns(tableSchema *bigquery.TableSchema) []*Column {
+	var result = make([]*Column, len(tableSchema.Fields))
+	for i, field := range tableSchema.Fields {
+		scanType, err := schema.BuildFieldType(field)
+		if err != nil {
+			scanType = interfaceType
+		}
+		result[i] = &Column{
+			Name:        field.Name,
+			Type:        field.Type,
+			Mode:        field.Mode,
+			Description: field.Description,
+			ScanType:    scanType,
+			Schema:      field,
+		}
+	}
+	return result
+}
+
+func fromMillis(millis int64) time.Time {
+	if millis == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(millis).UTC()
+}
+
+func optionalMillis(millis int64) *time.Time {
+	if millis == 0 {
+		return nil
+	}
+	result := fromMillis(millis)
+	return &result
+}
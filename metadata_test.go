@@ -0,0 +1,104 @@
+package bigquery
+
+import (
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/bigquery/v2"
+)
+
+func TestNewTable(t *testing.T) {
+	table := &bigquery.Table{
+		TableReference: &bigquery.TableReference{ProjectId: "p", DatasetId: "d", TableId: "t"},
+		Type:           "TABLE",
+		NumRows:        3,
+		CreationTime:   1700000000000,
+		Labels:         map[string]string{"team": "ads"},
+		TimePartitioning: &bigquery.TimePartitioning{
+			Type:  "DAY",
+			Field: "created",
+		},
+		Schema: &bigquery.TableSchema{Fields: []*bigquery.TableFieldSchema{
+			{Name: "id", Type: "INTEGER", Mode: "REQUIRED"},
+			{Name: "name", Type: "STRING", Mode: "NULLABLE"},
+			{Name: "created", Type: "TIMESTAMP"},
+			{Name: "area", Type: "GEOGRAPHY", Mode: "NULLABLE"},
+			{Name: "payload", Type: "JSON", Mode: "NULLABLE"},
+		}},
+	}
+	actual := newTable(table)
+	assert.Equal(t, "p", actual.ProjectID)
+	assert.Equal(t, "d", actual.DatasetID)
+	assert.Equal(t, "t", actual.ID)
+	assert.EqualValues(t, 3, actual.NumRows)
+	assert.Equal(t, time.UnixMilli(1700000000000).UTC(), actual.Created)
+	assert.Nil(t, actual.Expiration)
+	assert.Equal(t, "DAY", actual.TimePartitioning.Type)
+	assert.Equal(t, map[string]string{"team": "ads"}, actual.Labels)
+	if !assert.Len(t, actual.Columns, 5) {
+		return
+	}
+	assert.Equal(t, reflect.TypeOf(0), actual.Columns[0].ScanType)
+	assert.False(t, actual.Columns[0].Nullable())
+	assert.Equal(t, reflect.TypeOf((*string)(nil)), actual.Columns[1].ScanType)
+	assert.True(t, actual.Columns[1].Nullable())
+	assert.True(t, actual.Columns[2].Nullable())
+	assert.Equal(t, interfaceType, actual.Columns[3].ScanType)
+	assert.Equal(t, interfaceType, actual.Columns[4].ScanType)
+}
+
+func TestConnection_DatasetReference(t *testing.T) {
+	c := &connection{projectID: "p", cfg: &Config{DatasetID: "d"}}
+	var testCases = []struct {
+		dataset       string
+		expectProject string
+		expectDataset string
+	}{
+		{dataset: "", expectProject: "p", expectDataset: "d"},
+		{dataset: "ds", expectProject: "p", expectDataset: "ds"},
+		{dataset: "`other.ds`", expectProject: "other", expectDataset: "ds"},
+	}
+	for _, testCase := range testCases {
+		projectID, datasetID := c.datasetReference(testCase.dataset)
+		assert.Equal(t, testCase.expectProject, projectID, testCase.dataset)
+		assert.Equal(t, testCase.expectDataset, datasetID, testCase.dataset)
+	}
+}
+
+func TestCommandRows_Describe(t *testing.T) {
+	fields := []*bigquery.TableFieldSchema{
+		{Name: "id", Type: "INTEGER", Mode: "REQUIRED"},
+		{Name: "address", Type: "RECORD", Fields: []*bigquery.TableFieldSchema{
+			{Name: "city", Type: "STRING", Mode: "NULLABLE", Description: "city name"},
+		}},
+		{Name: "tags", Type: "STRING", Mode: "REPEATED"},
+		{Name: "area", Type: "GEOGRAPHY", Mode: "NULLABLE"},
+	}
+	rows, err := newCommandRows(describeSchema, columnValues(fields))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"ordinal_position", "column_name", "data_type", "mode", "is_nullable", "description"}, rows.Columns())
+	assert.Equal(t, reflect.TypeOf(0), rows.ColumnTypeScanType(0))
+	assert.Equal(t, "STRING", rows.ColumnTypeDatabaseTypeName(1))
+	var actual [][]driver.Value
+	for {
+		dest := make([]driver.Value, len(rows.Columns()))
+		if err = rows.Next(dest); err != nil {
+			break
+		}
+		actual = append(actual, dest)
+	}
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, [][]driver.Value{
+		{int64(1), "id", "INTEGER", "REQUIRED", "NO", ""},
+		{int64(2), "address", "STRUCT<city STRING>", "NULLABLE", "YES", ""},
+		{int64(3), "address.city", "STRING", "NULLABLE", "YES", "city name"},
+		{int64(4), "tags", "ARRAY<STRING>", "REPEATED", "NO", ""},
+		{int64(5), "area", "GEOGRAPHY", "NULLABLE", "YES", ""},
+	}, actual)
+}
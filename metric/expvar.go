@@ -0,0 +1,60 @@
+package metric
+
+import (
+	"expvar"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Expvar publishes metrics as expvar maps keyed by label values, i.e. bigquery_jobs_submitted_total: {"kind=query": 3},
+// observed metrics publish <name>_count and <name>_sum maps.
+type Expvar struct {
+	mux  sync.Mutex
+	maps map[string]*expvar.Map
+}
+
+// Add increments counter
+func (e *Expvar) Add(name string, value float64, labels map[string]string) {
+	e.publish(name).AddFloat(labelKey(labels), value)
+}
+
+// Observe records sample count and sum
+func (e *Expvar) Observe(name string, value float64, labels map[string]string) {
+	key := labelKey(labels)
+	e.publish(name+"_count").AddFloat(key, 1)
+	e.publish(name+"_sum").AddFloat(key, value)
+}
+
+// publish returns published map, maps already published by another Expvar are reused
+func (e *Expvar) publish(name string) *expvar.Map {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	if result, ok := e.maps[name]; ok {
+		return result
+	}
+	result, ok := expvar.Get(name).(*expvar.Map)
+	if !ok {
+		result = expvar.NewMap(name)
+	}
+	e.maps[name] = result
+	return result
+}
+
+// labelKey returns sorted key=value pairs joined with comma, empty labels use "total" key
+func labelKey(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "total"
+	}
+	var pairs = make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// NewExpvar creates expvar recorder
+func NewExpvar() *Expvar {
+	return &Expvar{maps: map[string]*expvar.Map{}}
+}
@@ -0,0 +1,84 @@
+// Package metric exposes driver metrics through a pluggable Recorder.
+//
+// Metric names and labels follow Prometheus conventions, every metric uses a fixed label set,
+// thus a recorder can map them directly onto counter and histogram vectors.
+package metric
+
+import (
+	"sync/atomic"
+)
+
+// Metric names
+const (
+	// JobsSubmitted counts submitted jobs and stream batches, labels: kind (query, load, extract, copy, stream)
+	JobsSubmitted = "bigquery_jobs_submitted_total"
+	// JobWaitSeconds observes time spent waiting for job completion, labels: kind
+	JobWaitSeconds = "bigquery_job_wait_seconds"
+	// PageFetchSeconds observes query results page fetch latency, no labels
+	PageFetchSeconds = "bigquery_page_fetch_seconds"
+	// RowsDecoded counts fetched and decoded query result rows, no labels
+	RowsDecoded = "bigquery_rows_decoded_total"
+	// BytesBilled counts billed bytes of completed jobs, labels: kind
+	BytesBilled = "bigquery_bytes_billed_total"
+	// Retries counts retried API calls, labels: reason
+	Retries = "bigquery_retries_total"
+	// InsertErrors counts rows rejected by tabledata.insertAll, no labels
+	InsertErrors = "bigquery_insert_errors_total"
+)
+
+// Label keys
+const (
+	KindLabel   = "kind"
+	ReasonLabel = "reason"
+)
+
+// Recorder records driver metrics
+type Recorder interface {
+	// Add increments counter by value
+	Add(name string, value float64, labels map[string]string)
+	// Observe records a histogram sample, durations are observed in seconds
+	Observe(name string, value float64, labels map[string]string)
+}
+
+type recorderHolder struct {
+	Recorder
+}
+
+var recorder atomic.Value
+
+// Set sets driver metrics recorder, nil disables metrics
+func Set(aRecorder Recorder) {
+	recorder.Store(recorderHolder{aRecorder})
+}
+
+// Get returns driver metrics recorder or nil
+func Get() Recorder {
+	if holder, ok := recorder.Load().(recorderHolder); ok {
+		return holder.Recorder
+	}
+	return nil
+}
+
+// Add increments counter with the registered recorder
+func Add(name string, value float64, labels map[string]string) {
+	if aRecorder := Get(); aRecorder != nil {
+		aRecorder.Add(name, value, labels)
+	}
+}
+
+// Observe records a sample with the registered recorder
+func Observe(name string, value float64, labels map[string]string) {
+	if aRecorder := Get(); aRecorder != nil {
+		aRecorder.Observe(name, value, labels)
+	}
+}
+
+// Kind returns kind label
+func Kind(kind string) map[string]string {
+	return map[string]string{KindLabel: kind}
+}
+
+// Reason returns reason label
+func Reason(reason string) map[string]string {
+	return map[string]string{ReasonLabel: reason}
+}
@@ -0,0 +1,73 @@
+package metric
+
+import (
+	"expvar"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpvar(t *testing.T) {
+	Set(NewExpvar())
+	defer Set(nil)
+	var testCases = []struct {
+		description string
+		record      func()
+		name        string
+		expect      string
+	}{
+		{
+			description: "counter with labels",
+			record: func() {
+				Add("test_jobs_total", 1, Kind("query"))
+				Add("test_jobs_total", 2, Kind("query"))
+				Add("test_jobs_total", 1, Kind("load"))
+			},
+			name:   "test_jobs_total",
+			expect: `{"kind=load": 1, "kind=query": 3}`,
+		},
+		{
+			description: "counter without labels",
+			record: func() {
+				Add("test_rows_total", 10, nil)
+			},
+			name:   "test_rows_total",
+			expect: `{"total": 10}`,
+		},
+		{
+			description: "observation count",
+			record: func() {
+				Observe("test_wait_seconds", 0.5, Kind("query"))
+				Observe("test_wait_seconds", 1.5, Kind("query"))
+			},
+			name:   "test_wait_seconds_count",
+			expect: `{"kind=query": 2}`,
+		},
+		{
+			description: "observation sum",
+			name:        "test_wait_seconds_sum",
+			expect:      `{"kind=query": 2}`,
+		},
+	}
+	for _, testCase := range testCases {
+		if testCase.record != nil {
+			testCase.record()
+		}
+		actual := expvar.Get(testCase.name)
+		if !assert.NotNil(t, actual, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expect, actual.String(), testCase.description)
+	}
+}
+
+func TestSet(t *testing.T) {
+	assert.Nil(t, Get())
+	Add("test_disabled_total", 1, nil)
+	assert.Nil(t, expvar.Get("test_disabled_total"))
+	recorder := NewExpvar()
+	Set(recorder)
+	assert.Equal(t, recorder, Get())
+	Set(nil)
+	assert.Nil(t, Get())
+}
@@ -1,9 +1,7 @@
 package bigquery
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/viant/afs"
@@ -35,8 +33,13 @@ type OAuth2Manager struct {
 	authorizer          *authorizer.Service
 	newAuthCodeEndpoint func() (flow.Endpoint, error)
 	baseURL             string
+	tokenKey            string
+	authFlow            string
+	usePKCE             bool
+	prompt              OAuth2PromptFunc
 }
 
+// Token authorizes access with the configured flow, the gcloud client and default scopes are used if not provided
 func (o *OAuth2Manager) Token(ctx context.Context, config *oauth2.Config, scopes ...string) (*oauth2.Token, error) {
 	if config == nil {
 		config = client.NewGCloud()
@@ -44,15 +47,22 @@ func (o *OAuth2Manager) Token(ctx context.Context, config *oauth2.Config, scopes
 	if len(scopes) == 0 {
 		scopes = defaultScopes
 	}
-	anAuthorizer := authorizer.New()
-	return anAuthorizer.Authorize(ctx, &authorizer.Command{
-		OAuthConfig: authorizer.OAuthConfig{Config: config},
-		AuthFlow:    "Browser",
-		Scopes:      scopes,
-		UsePKCE:     false,
-		NewEndpoint: o.newAuthCodeEndpoint,
-	})
-
+	switch o.authFlow {
+	case OAuth2FlowDevice:
+		return o.deviceToken(ctx, config, scopes)
+	case OAuth2FlowManual:
+		return o.manualToken(ctx, config, scopes)
+	case "", OAuth2FlowBrowser:
+		anAuthorizer := authorizer.New()
+		return anAuthorizer.Authorize(ctx, &authorizer.Command{
+			OAuthConfig: authorizer.OAuthConfig{Config: config},
+			AuthFlow:    OAuth2FlowBrowser,
+			Scopes:      scopes,
+			UsePKCE:     o.usePKCE,
+			NewEndpoint: o.newAuthCodeEndpoint,
+		})
+	}
+	return nil, fmt.Errorf("unsupported oauth2 flow: %v, supported: [%v|%v|%v]", o.authFlow, OAuth2FlowBrowser, OAuth2FlowDevice, OAuth2FlowManual)
 }
 
 // TokenSource returns oauth2.TokenSource constructed with provided config and token.
@@ -95,12 +105,7 @@ func (o *OAuth2Manager) TokenFromURL(ctx context.Context, URL string) (*auth.Tok
 	if err != nil {
 		return nil, err
 	}
-	token := &auth.Token{}
-	err = json.Unmarshal(data, &token)
-	if err != nil {
-		return nil, err
-	}
-	return token, nil
+	return o.decodeToken(ctx, URL, data)
 }
 
 func (o *OAuth2Manager) WithConfigURL(ctx context.Context, config *oauth2.Config) (string, error) {
@@ -116,12 +121,7 @@ func (o *OAuth2Manager) WithConfigURL(ctx context.Context, config *oauth2.Config
 
 func (o *OAuth2Manager) WithTokenURL(ctx context.Context, token *oauth2.Token) (string, error) {
 	URL := url.Join(o.baseURL, uuid.New().String())
-	data, err := json.Marshal(token)
-	if err != nil {
-		return URL, err
-	}
-	err = o.fs.Upload(ctx, URL, file.DefaultFileOsMode, bytes.NewReader(data))
-	return URL, err
+	return URL, o.uploadToken(ctx, URL, token)
 }
 
 func WithNewAuthCodeEndpoint(f func() (flow.Endpoint, error)) OAuth2Option {
@@ -136,6 +136,8 @@ func NewOAuth2Manager(opts ...OAuth2Option) *OAuth2Manager {
 		fs:         afs.New(),
 		authorizer: authorizer.New(),
 		baseURL:    "mem://localhost/bigquery/",
+		tokenKey:   defaultTokenKey,
+		prompt:     defaultPrompt(),
 	}
 	for _, opt := range opts {
 		opt(o)
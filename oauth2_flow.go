@@ -0,0 +1,176 @@
+package bigquery
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	neturl "net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	// OAuth2FlowBrowser authorizes with a local browser and loopback redirect
+	OAuth2FlowBrowser = "Browser"
+	// OAuth2FlowDevice authorizes with RFC 8628 device authorization, the user code is entered on another device
+	OAuth2FlowDevice = "Device"
+	// OAuth2FlowManual authorizes out-of-band, the user pastes the authorization code or the redirected URL
+	OAuth2FlowManual = "Manual"
+
+	manualRedirectURL = "http://localhost"
+)
+
+// OAuth2Prompt represents user interaction requested by headless flows
+type OAuth2Prompt struct {
+	Flow            string
+	URL             string // Manual flow authorization URL
+	VerificationURL string // Device flow verification URL
+	UserCode        string // Device flow user code
+	Expiry          time.Time
+}
+
+// OAuth2PromptFunc presents the prompt to the user, Manual flow expects the pasted authorization code or redirected URL
+type OAuth2PromptFunc func(ctx context.Context, prompt *OAuth2Prompt) (string, error)
+
+// WithAuthFlow sets authorization flow: Browser (default), Device or Manual
+func WithAuthFlow(flow string) OAuth2Option {
+	return func(o *OAuth2Manager) {
+		o.authFlow = flow
+	}
+}
+
+// WithPKCE enables PKCE for Browser and Manual flows
+func WithPKCE(enabled bool) OAuth2Option {
+	return func(o *OAuth2Manager) {
+		o.usePKCE = enabled
+	}
+}
+
+// WithPrompt sets prompt of Device and Manual flows, console prompt is used by default
+func WithPrompt(prompt OAuth2PromptFunc) OAuth2Option {
+	return func(o *OAuth2Manager) {
+		o.prompt = prompt
+	}
+}
+
+// WithBaseURL sets base URL of stored configs and tokens, i.e. file:///home/me/.secret/bigquery or gs://bucket/secrets
+func WithBaseURL(URL string) OAuth2Option {
+	return func(o *OAuth2Manager) {
+		o.baseURL = URL
+	}
+}
+
+// ConsolePrompt returns prompt writing instructions to writer, Manual flow reads the authorization code line from reader
+func ConsolePrompt(reader io.Reader, writer io.Writer) OAuth2PromptFunc {
+	lines := bufio.NewReader(reader)
+	return func(ctx context.Context, prompt *OAuth2Prompt) (string, error) {
+		if prompt.Flow == OAuth2FlowDevice {
+			_, err := fmt.Fprintf(writer, "Visit %v and enter code: %v\n", prompt.VerificationURL, prompt.UserCode)
+			return "", err
+		}
+		if _, err := fmt.Fprintf(writer, "Visit the URL below, authorize access, then paste the authorization code or the redirected URL:\n%v\n", prompt.URL); err != nil {
+			return "", err
+		}
+		line, err := lines.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return "", fmt.Errorf("failed to read authorization code: %w", err)
+		}
+		return strings.TrimSpace(line), nil
+	}
+}
+
+// deviceToken returns token of the device authorization flow, Google endpoints default to Google device authorization URL
+func (o *OAuth2Manager) deviceToken(ctx context.Context, config *oauth2.Config, scopes []string) (*oauth2.Token, error) {
+	cfg := *config
+	cfg.Scopes = scopes
+	if cfg.Endpoint.DeviceAuthURL == "" {
+		if cfg.Endpoint.TokenURL != google.Endpoint.TokenURL {
+			return nil, fmt.Errorf("failed to start device authorization: device authorization URL was empty")
+		}
+		cfg.Endpoint.DeviceAuthURL = google.Endpoint.DeviceAuthURL
+	}
+	response, err := cfg.DeviceAuth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start device authorization: %w", err)
+	}
+	verificationURL := response.VerificationURIComplete
+	if verificationURL == "" {
+		verificationURL = response.VerificationURI
+	}
+	if _, err = o.prompt(ctx, &OAuth2Prompt{Flow: OAuth2FlowDevice, VerificationURL: verificationURL, UserCode: response.UserCode, Expiry: response.Expiry}); err != nil {
+		return nil, err
+	}
+	token, err := cfg.DeviceAccessToken(ctx, response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete device authorization: %w", err)
+	}
+	return token, nil
+}
+
+// manualToken returns token of the out-of-band flow, the authorization code is supplied by the prompt
+func (o *OAuth2Manager) manualToken(ctx context.Context, config *oauth2.Config, scopes []string) (*oauth2.Token, error) {
+	cfg := *config
+	cfg.Scopes = scopes
+	if cfg.RedirectURL == "" {
+		cfg.RedirectURL = manualRedirectURL
+	}
+	state := uuid.New().String()
+	authOptions := []oauth2.AuthCodeOption{oauth2.AccessTypeOffline, oauth2.ApprovalForce}
+	var exchangeOptions []oauth2.AuthCodeOption
+	if o.usePKCE {
+		verifier := oauth2.GenerateVerifier()
+		authOptions = append(authOptions, oauth2.S256ChallengeOption(verifier))
+		exchangeOptions = append(exchangeOptions, oauth2.VerifierOption(verifier))
+	}
+	input, err := o.prompt(ctx, &OAuth2Prompt{Flow: OAuth2FlowManual, URL: cfg.AuthCodeURL(state, authOptions...)})
+	if err != nil {
+		return nil, err
+	}
+	code, err := authorizationCode(input, state)
+	if err != nil {
+		return nil, err
+	}
+	token, err := cfg.Exchange(ctx, code, exchangeOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	return token, nil
+}
+
+// authorizationCode returns pasted authorization code, or code of the pasted redirected URL with matching state
+func authorizationCode(input, state string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", fmt.Errorf("authorization code was empty")
+	}
+	if !strings.Contains(input, "?") {
+		return input, nil
+	}
+	URL, err := neturl.Parse(input)
+	if err != nil {
+		return "", fmt.Errorf("invalid redirected URL: %v, %w", input, err)
+	}
+	query := URL.Query()
+	if reason := query.Get("error"); reason != "" {
+		return "", fmt.Errorf("authorization failed: %v", reason)
+	}
+	if actual := query.Get("state"); actual != state {
+		return "", fmt.Errorf("invalid redirected URL state: %v, expected: %v", actual, state)
+	}
+	code := query.Get("code")
+	if code == "" {
+		return "", fmt.Errorf("redirected URL has no authorization code: %v", input)
+	}
+	return code, nil
+}
+
+// defaultPrompt returns console prompt
+func defaultPrompt() OAuth2PromptFunc {
+	return ConsolePrompt(os.Stdin, os.Stderr)
+}
@@ -2,9 +2,18 @@ package bigquery
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/scy/auth"
 	"golang.org/x/oauth2"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -73,3 +82,216 @@ func TestOauth2_TokenSource(t *testing.T) {
 		assert.EqualValues(t, tc.token.AccessToken, gotToken.AccessToken, tc.name)
 	}
 }
+
+func TestOAuth2Manager_PersistingTokenSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "refreshed", "token_type": "Bearer", "refresh_token": "rotated", "expires_in": 3600}`))
+	}))
+	defer server.Close()
+	cfg := &oauth2.Config{ClientID: "clientID", ClientSecret: "clientSecret", Endpoint: oauth2.Endpoint{TokenURL: server.URL}}
+
+	var testCases = []struct {
+		description string
+		options     []OAuth2Option
+		stored      *oauth2.Token
+		expectToken string
+		expectPlain bool
+	}{
+		{
+			description: "encrypted with default key",
+			stored:      &oauth2.Token{AccessToken: "expired", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Hour)},
+			expectToken: "refreshed",
+		},
+		{
+			description: "plain JSON",
+			options:     []OAuth2Option{WithTokenKey("")},
+			stored:      &oauth2.Token{AccessToken: "expired", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Hour)},
+			expectToken: "refreshed",
+			expectPlain: true,
+		},
+	}
+	for _, testCase := range testCases {
+		ctx := context.Background()
+		baseURL := t.TempDir()
+		helper := NewOAuth2Manager(append(testCase.options, func(o *OAuth2Manager) { o.baseURL = baseURL })...)
+		URL, err := helper.WithTokenURL(ctx, testCase.stored)
+		if !assert.NoError(t, err, testCase.description) {
+			continue
+		}
+		data, err := os.ReadFile(URL)
+		assert.NoError(t, err, testCase.description)
+		assert.Equal(t, testCase.expectPlain, json.Valid(data), testCase.description)
+
+		token, err := helper.TokenFromURL(ctx, URL)
+		if !assert.NoError(t, err, testCase.description) {
+			continue
+		}
+		source, err := helper.PersistingTokenSource(ctx, cfg, token, URL)
+		if !assert.NoError(t, err, testCase.description) {
+			continue
+		}
+		refreshed, err := source.Token()
+		assert.NoError(t, err, testCase.description)
+		assert.Equal(t, testCase.expectToken, refreshed.AccessToken, testCase.description)
+
+		persisted, err := helper.TokenFromURL(ctx, URL)
+		if assert.NoError(t, err, testCase.description) {
+			assert.Equal(t, testCase.expectToken, persisted.AccessToken, testCase.description)
+			assert.Equal(t, "rotated", persisted.RefreshToken, testCase.description)
+		}
+		entries, _ := os.ReadDir(baseURL)
+		for _, entry := range entries {
+			assert.False(t, strings.Contains(entry.Name(), ".tmp-"), testCase.description)
+		}
+	}
+}
+
+func TestOAuth2Manager_StoreToken(t *testing.T) {
+	var testCases = []struct {
+		description string
+		baseURL     string
+		hasError    bool
+	}{
+		{description: "local file", baseURL: t.TempDir()},
+		{description: "memory", baseURL: "mem://localhost/bigquery/store"},
+		{description: "unsupported scheme", baseURL: "ftp://localhost/bigquery", hasError: true},
+	}
+	for _, testCase := range testCases {
+		ctx := context.Background()
+		helper := NewOAuth2Manager(func(o *OAuth2Manager) { o.baseURL = testCase.baseURL })
+		URL := testCase.baseURL + "/" + uuid.New().String()
+		if testCase.hasError {
+			assert.Error(t, helper.storeToken(ctx, URL, &oauth2.Token{AccessToken: "token"}), testCase.description)
+			continue
+		}
+		var wg sync.WaitGroup
+		for i := 1; i <= 4; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				token := &oauth2.Token{AccessToken: fmt.Sprintf("token%d", i), Expiry: time.Now().Add(time.Duration(i) * time.Hour)}
+				assert.NoError(t, helper.storeToken(ctx, URL, token), testCase.description)
+			}(i)
+		}
+		wg.Wait()
+		assert.NoError(t, helper.storeToken(ctx, URL, &oauth2.Token{AccessToken: "older", Expiry: time.Now().Add(time.Hour)}), testCase.description)
+		stored, err := helper.TokenFromURL(ctx, URL)
+		if assert.NoError(t, err, testCase.description) {
+			assert.Equal(t, "token4", stored.AccessToken, testCase.description)
+		}
+	}
+}
+
+func TestOAuth2Manager_Token(t *testing.T) {
+	var form = map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		for k := range r.PostForm {
+			form[k] = r.PostForm.Get(k)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/device":
+			_, _ = w.Write([]byte(`{"device_code": "device123", "user_code": "ABCD-EFGH", "verification_url": "https://example.com/device", "expires_in": 60, "interval": 1}`))
+		default:
+			_, _ = w.Write([]byte(`{"access_token": "issued", "token_type": "Bearer", "refresh_token": "refresh", "expires_in": 3600}`))
+		}
+	}))
+	defer server.Close()
+	cfg := &oauth2.Config{ClientID: "clientID", ClientSecret: "clientSecret", Endpoint: oauth2.Endpoint{
+		AuthURL:       server.URL + "/auth",
+		TokenURL:      server.URL + "/token",
+		DeviceAuthURL: server.URL + "/device",
+	}}
+
+	var testCases = []struct {
+		description string
+		flow        string
+		pkce        bool
+		prompt      OAuth2PromptFunc
+		expectForm  map[string]string
+		hasVerifier bool
+		hasError    bool
+	}{
+		{
+			description: "manual flow with pasted redirected URL",
+			flow:        OAuth2FlowManual,
+			pkce:        true,
+			prompt: func(ctx context.Context, prompt *OAuth2Prompt) (string, error) {
+				URL, _ := url.Parse(prompt.URL)
+				if URL.Query().Get("code_challenge") == "" {
+					return "", fmt.Errorf("code challenge was empty")
+				}
+				return "http://localhost/?state=" + URL.Query().Get("state") + "&code=code123", nil
+			},
+			expectForm:  map[string]string{"grant_type": "authorization_code", "code": "code123", "redirect_uri": "http://localhost"},
+			hasVerifier: true,
+		},
+		{
+			description: "manual flow with pasted code without PKCE",
+			flow:        OAuth2FlowManual,
+			prompt: func(ctx context.Context, prompt *OAuth2Prompt) (string, error) {
+				return " code456\n", nil
+			},
+			expectForm: map[string]string{"grant_type": "authorization_code", "code": "code456"},
+		},
+		{
+			description: "device flow",
+			flow:        OAuth2FlowDevice,
+			prompt: func(ctx context.Context, prompt *OAuth2Prompt) (string, error) {
+				if prompt.UserCode != "ABCD-EFGH" || prompt.VerificationURL != "https://example.com/device" {
+					return "", fmt.Errorf("unexpected prompt: %+v", prompt)
+				}
+				return "", nil
+			},
+			expectForm: map[string]string{"grant_type": "urn:ietf:params:oauth:grant-type:device_code", "device_code": "device123"},
+		},
+		{
+			description: "unsupported flow",
+			flow:        "Telepathy",
+			hasError:    true,
+		},
+	}
+	for _, testCase := range testCases {
+		form = map[string]string{}
+		helper := NewOAuth2Manager(WithAuthFlow(testCase.flow), WithPKCE(testCase.pkce), WithPrompt(testCase.prompt))
+		token, err := helper.Token(context.Background(), cfg, "scope1")
+		if testCase.hasError {
+			assert.Error(t, err, testCase.description)
+			continue
+		}
+		if !assert.NoError(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, "issued", token.AccessToken, testCase.description)
+		for k, v := range testCase.expectForm {
+			assert.Equal(t, v, form[k], testCase.description+" "+k)
+		}
+		assert.Equal(t, testCase.hasVerifier, form["code_verifier"] != "", testCase.description)
+	}
+}
+
+func TestAuthorizationCode(t *testing.T) {
+	var testCases = []struct {
+		description string
+		input       string
+		expect      string
+		hasError    bool
+	}{
+		{description: "pasted code", input: " 4/abc \n", expect: "4/abc"},
+		{description: "redirected URL", input: "http://localhost/?state=s1&code=4%2Fabc&scope=x", expect: "4/abc"},
+		{description: "state mismatch", input: "http://localhost/?state=other&code=abc", hasError: true},
+		{description: "access denied", input: "http://localhost/?error=access_denied&state=s1", hasError: true},
+		{description: "empty", input: " ", hasError: true},
+	}
+	for _, testCase := range testCases {
+		actual, err := authorizationCode(testCase.input, "s1")
+		if testCase.hasError {
+			assert.Error(t, err, testCase.description)
+			continue
+		}
+		assert.NoError(t, err, testCase.description)
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
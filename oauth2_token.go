@@ -0,0 +1,206 @@
+package bigquery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/mem"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"github.com/viant/bigquery/internal/logging"
+	"github.com/viant/scy"
+	"github.com/viant/scy/auth"
+	"golang.org/x/oauth2"
+)
+
+const (
+	defaultTokenKey   = "blowfish://default"
+	gsScheme          = "gs"
+	tokenStoreRetries = 8
+	tokenStoreWait    = 50 * time.Millisecond
+	tokenLockTimeout  = 30 * time.Second
+)
+
+// WithTokenKey sets scy key (i.e. blowfish://default) encrypting stored tokens, empty key stores plain JSON tokens
+func WithTokenKey(key string) OAuth2Option {
+	return func(o *OAuth2Manager) {
+		o.tokenKey = key
+	}
+}
+
+// PersistingTokenSource returns token source refreshing the token like TokenSource, refreshed tokens are stored
+// back to the token URL, thus a restarted process loads the latest token
+func (o *OAuth2Manager) PersistingTokenSource(ctx context.Context, cfg *oauth2.Config, token *auth.Token, URL string) (oauth2.TokenSource, error) {
+	source, err := o.TokenSource(ctx, cfg, token)
+	if err != nil {
+		return nil, err
+	}
+	return &persistingTokenSource{ctx: ctx, manager: o, source: source, URL: URL, last: token.AccessToken}, nil
+}
+
+// persistingTokenSource represents token source storing refreshed tokens to the token URL
+type persistingTokenSource struct {
+	ctx     context.Context
+	manager *OAuth2Manager
+	source  oauth2.TokenSource
+	URL     string
+	mux     sync.Mutex
+	last    string
+}
+
+// Token returns token, a refreshed token is stored outside the lock, store failures are logged as the token is still valid
+func (p *persistingTokenSource) Token() (*oauth2.Token, error) {
+	token, err := p.source.Token()
+	if err != nil {
+		return nil, err
+	}
+	p.mux.Lock()
+	refreshed := token.AccessToken != p.last
+	p.last = token.AccessToken
+	p.mux.Unlock()
+	if !refreshed {
+		return token, nil
+	}
+	if err = p.manager.storeToken(p.ctx, p.URL, token); err != nil {
+		logging.FromContext(p.ctx).Warn("failed to store refreshed oauth2 token", "url", p.URL, "error", err)
+	}
+	return token, nil
+}
+
+// storeToken stores token at URL unless the stored token expires later (i.e. refreshed by another process),
+// concurrent writers are serialised with generation preconditions (gs, mem) or a lock file (local files),
+// other schemes are not supported
+func (o *OAuth2Manager) storeToken(ctx context.Context, URL string, token *oauth2.Token) error {
+	var err error
+	switch scheme := url.Scheme(URL, file.Scheme); scheme {
+	case gsScheme, mem.Scheme:
+		err = o.storeTokenWithGeneration(ctx, URL, scheme, token)
+	case file.Scheme:
+		err = o.storeTokenWithLock(ctx, URL, token)
+	default:
+		err = fmt.Errorf("unsupported scheme: %v, supported: [%v|%v|%v]", scheme, gsScheme, mem.Scheme, file.Scheme)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store token: %v, %w", URL, err)
+	}
+	return nil
+}
+
+// storeTokenWithGeneration uploads token only if the stored token generation has not changed since it was read,
+// the read, check and upload are retried when another writer wins
+func (o *OAuth2Manager) storeTokenWithGeneration(ctx context.Context, URL, scheme string, token *oauth2.Token) error {
+	wait := tokenStoreWait
+	for i := 0; ; i++ {
+		generation := &option.Generation{WhenMatch: true}
+		data, err := o.fs.DownloadWithURL(ctx, URL, generation)
+		if err != nil {
+			if exists, _ := o.fs.Exists(ctx, URL); exists {
+				return err
+			}
+			generation.Generation = 0 //create only if still missing
+		} else if stored, err := o.decodeToken(ctx, URL, data); err == nil && stored.Expiry.After(token.Expiry) {
+			return nil
+		}
+		err = o.uploadToken(ctx, URL, token, generation)
+		if err == nil || o.fs.ErrorCode(scheme, err) != http.StatusPreconditionFailed || i+1 >= tokenStoreRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+// storeTokenWithLock replaces local token with a move of the uploaded temporary file while holding a lock file
+func (o *OAuth2Manager) storeTokenWithLock(ctx context.Context, URL string, token *oauth2.Token) error {
+	unlock, err := lockFile(ctx, url.Path(URL)+".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if stored, err := o.TokenFromURL(ctx, URL); err == nil && stored.Expiry.After(token.Expiry) {
+		return nil
+	}
+	tempURL := URL + ".tmp-" + uuid.New().String()
+	if err := o.uploadToken(ctx, tempURL, token); err != nil {
+		return err
+	}
+	if err := o.fs.Move(ctx, tempURL, URL); err != nil {
+		_ = o.fs.Delete(ctx, tempURL)
+		return err
+	}
+	return nil
+}
+
+// lockFile creates lock file if absent, retrying while other process holds it, a lock older than tokenLockTimeout is abandoned
+func lockFile(ctx context.Context, path string) (func(), error) {
+	wait := tokenStoreWait
+	for i := 0; ; i++ {
+		lock, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, file.DefaultFileOsMode)
+		if err == nil {
+			_ = lock.Close()
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > tokenLockTimeout {
+			_ = os.Remove(path)
+			continue
+		}
+		if i+1 >= tokenStoreRetries {
+			return nil, fmt.Errorf("failed to acquire lock: %v", path)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+// uploadToken uploads token encrypted with the token key, or plain JSON if the key is empty
+func (o *OAuth2Manager) uploadToken(ctx context.Context, URL string, token *oauth2.Token, options ...storage.Option) error {
+	if o.tokenKey != "" {
+		resource := scy.NewResource(nil, URL, o.tokenKey)
+		resource.Options = options
+		return o.secrets.Store(ctx, scy.NewSecret(token, resource))
+	}
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	return o.fs.Upload(ctx, URL, file.DefaultFileOsMode, bytes.NewReader(data), options...)
+}
+
+// decodeToken decodes plain JSON or encrypted token
+func (o *OAuth2Manager) decodeToken(ctx context.Context, URL string, data []byte) (*auth.Token, error) {
+	if !json.Valid(data) {
+		if o.tokenKey == "" {
+			return nil, fmt.Errorf("failed to decode token: %v, token key was empty", URL)
+		}
+		secret, err := o.secrets.Load(ctx, &scy.Resource{Name: "token", URL: URL, Key: o.tokenKey, Data: data})
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt token: %v, %w", URL, err)
+		}
+		data = []byte(secret.String())
+	}
+	token := &auth.Token{}
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
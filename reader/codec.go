@@ -0,0 +1,67 @@
+package reader
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"github.com/klauspost/compress/zstd"
+	"io"
+	"strings"
+)
+
+const (
+	// CodecNone means uncompressed data
+	CodecNone = "none"
+	// CodecGzip means gzip compressed data
+	CodecGzip = "gzip"
+	// CodecZstd means zstd compressed data
+	CodecZstd = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// IsCodec returns true if codec is supported
+func IsCodec(codec string) bool {
+	switch strings.ToLower(codec) {
+	case CodecNone, CodecGzip, CodecZstd:
+		return true
+	}
+	return false
+}
+
+// DetectCodec detects data codec with magic number, returned reader replays the inspected header
+func DetectCodec(reader io.Reader) (string, io.Reader, error) {
+	bufReader := bufio.NewReader(reader)
+	header, err := bufReader.Peek(len(zstdMagic))
+	if err != nil && err != io.EOF {
+		return "", nil, err
+	}
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		return CodecGzip, bufReader, nil
+	case bytes.HasPrefix(header, zstdMagic):
+		return CodecZstd, bufReader, nil
+	}
+	return CodecNone, bufReader, nil
+}
+
+// Decompress returns a reader decompressing data with the supplied codec, caller has to close it once reading is done
+func Decompress(reader io.Reader, codec string) (io.ReadCloser, error) {
+	switch strings.ToLower(codec) {
+	case CodecNone, "":
+		return io.NopCloser(reader), nil
+	case CodecGzip:
+		return gzip.NewReader(reader)
+	case CodecZstd:
+		decoder, err := zstd.NewReader(reader, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	}
+	return nil, fmt.Errorf("unsupported codec: %v, supported: [%v|%v|%v]", codec, CodecNone, CodecGzip, CodecZstd)
+}
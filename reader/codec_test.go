@@ -0,0 +1,56 @@
+package reader
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectCodec(t *testing.T) {
+	data := "id,name\n1,abc\n"
+	gzipped, err := Gzip(strings.NewReader(data))
+	if !assert.Nil(t, err) {
+		return
+	}
+	zstdBuffer := new(bytes.Buffer)
+	encoder, _ := zstd.NewWriter(zstdBuffer)
+	_, _ = encoder.Write([]byte(data))
+	_ = encoder.Close()
+
+	var testCases = []struct {
+		description string
+		reader      io.Reader
+		expect      string
+	}{
+		{description: "plain", reader: strings.NewReader(data), expect: CodecNone},
+		{description: "empty", reader: strings.NewReader(""), expect: CodecNone},
+		{description: "gzip", reader: gzipped, expect: CodecGzip},
+		{description: "zstd", reader: zstdBuffer, expect: CodecZstd},
+	}
+	for _, testCase := range testCases {
+		codec, reader, err := DetectCodec(testCase.reader)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expect, codec, testCase.description)
+		decompressed, err := Decompress(reader, codec)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		actual, err := io.ReadAll(decompressed)
+		assert.Nil(t, err, testCase.description)
+		assert.Nil(t, decompressed.Close(), testCase.description)
+		if testCase.description != "empty" {
+			assert.Equal(t, data, string(actual), testCase.description)
+		}
+	}
+}
+
+func TestDecompress_UnsupportedCodec(t *testing.T) {
+	_, err := Decompress(strings.NewReader(""), "lz4")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,306 @@
+package reader
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/bigquery/internal/schema"
+	"github.com/viant/bigquery/internal/wire"
+	"google.golang.org/api/bigquery/v2"
+	"io"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	ratType  = reflect.TypeOf(big.Rat{})
+)
+
+// Encoder lazily encodes Go records as newline delimited JSON in BigQuery wire format,
+// registered encoder provides load schema and stream insert ID field.
+type Encoder struct {
+	next          func() (reflect.Value, bool)
+	fields        []*fieldEncoder
+	tableSchema   *bigquery.TableSchema
+	insertIDField string
+	buffer        bytes.Buffer
+	err           error
+}
+
+type fieldEncoder struct {
+	index  []int
+	key    []byte
+	encode func(buffer *bytes.Buffer, value reflect.Value) error
+}
+
+// FromSlice creates an encoder producing newline delimited JSON from records
+func FromSlice[T any](records []T) (*Encoder, error) {
+	i := 0
+	return newEncoder(reflect.TypeOf((*T)(nil)).Elem(), func() (reflect.Value, bool) {
+		if i >= len(records) {
+			return reflect.Value{}, false
+		}
+		i++
+		return reflect.ValueOf(&records[i-1]).Elem(), true
+	})
+}
+
+// FromChannel creates an encoder producing newline delimited JSON from records received until channel is closed
+func FromChannel[T any](records <-chan T) (*Encoder, error) {
+	return newEncoder(reflect.TypeOf((*T)(nil)).Elem(), func() (reflect.Value, bool) {
+		record, ok := <-records
+		if !ok {
+			return reflect.Value{}, false
+		}
+		return reflect.ValueOf(&record).Elem(), true
+	})
+}
+
+// Schema returns table schema derived from record type
+func (e *Encoder) Schema() *bigquery.TableSchema {
+	return e.tableSchema
+}
+
+// InsertIDField returns name of the field tagged with insertID option
+func (e *Encoder) InsertIDField() string {
+	return e.insertIDField
+}
+
+// Read reads encoded records
+func (e *Encoder) Read(p []byte) (int, error) {
+	for e.buffer.Len() == 0 {
+		if e.err != nil {
+			return 0, e.err
+		}
+		record, ok := e.next()
+		if !ok {
+			e.err = io.EOF
+			continue
+		}
+		e.buffer.Reset()
+		if err := e.encodeRecord(&e.buffer, record); err != nil {
+			e.err = err
+			e.buffer.Reset()
+			continue
+		}
+		e.buffer.WriteByte('\n')
+	}
+	return e.buffer.Read(p)
+}
+
+func (e *Encoder) encodeRecord(buffer *bytes.Buffer, record reflect.Value) error {
+	for record.Kind() == reflect.Ptr {
+		if record.IsNil() {
+			return fmt.Errorf("record was nil")
+		}
+		record = record.Elem()
+	}
+	return encodeStruct(buffer, record, e.fields)
+}
+
+func newEncoder(rType reflect.Type, next func() (reflect.Value, bool)) (*Encoder, error) {
+	structFields, err := schema.StructFields(rType)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := newFieldEncoders(structFields)
+	if err != nil {
+		return nil, err
+	}
+	result := &Encoder{next: next, fields: fields, tableSchema: &bigquery.TableSchema{}}
+	for _, field := range structFields {
+		result.tableSchema.Fields = append(result.tableSchema.Fields, field.Schema)
+		if field.Tag.InsertID {
+			result.insertIDField = field.Schema.Name
+		}
+	}
+	return result, nil
+}
+
+func newFieldEncoders(structFields []*schema.StructField) ([]*fieldEncoder, error) {
+	var result = make([]*fieldEncoder, len(structFields))
+	for i, field := range structFields {
+		key, _ := json.Marshal(field.Schema.Name)
+		encode, err := newValueEncoder(field)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create encoder for field: %v, %w", field.Schema.Name, err)
+		}
+		if field.Schema.Mode == "REPEATED" {
+			encode = newRepeatedEncoder(encode)
+		}
+		result[i] = &fieldEncoder{index: field.Index, key: key, encode: encode}
+	}
+	return result, nil
+}
+
+func encodeStruct(buffer *bytes.Buffer, value reflect.Value, fields []*fieldEncoder) error {
+	buffer.WriteByte('{')
+	for i, field := range fields {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		buffer.Write(field.key)
+		buffer.WriteByte(':')
+		if err := field.encode(buffer, value.FieldByIndex(field.index)); err != nil {
+			return err
+		}
+	}
+	buffer.WriteByte('}')
+	return nil
+}
+
+func newRepeatedEncoder(encode func(buffer *bytes.Buffer, value reflect.Value) error) func(buffer *bytes.Buffer, value reflect.Value) error {
+	return func(buffer *bytes.Buffer, value reflect.Value) error {
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				buffer.WriteString("[]")
+				return nil
+			}
+			value = value.Elem()
+		}
+		buffer.WriteByte('[')
+		for i := 0; i < value.Len(); i++ {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			if err := encode(buffer, value.Index(i)); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(']')
+		return nil
+	}
+}
+
+// newValueEncoder returns encoder of a single field value, nil pointers are encoded as null
+func newValueEncoder(field *schema.StructField) (func(buffer *bytes.Buffer, value reflect.Value) error, error) {
+	encode, err := newBasicEncoder(field)
+	if err != nil {
+		return nil, err
+	}
+	return func(buffer *bytes.Buffer, value reflect.Value) error {
+		for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				buffer.WriteString("null")
+				return nil
+			}
+			value = value.Elem()
+		}
+		return encode(buffer, value)
+	}, nil
+}
+
+func newBasicEncoder(field *schema.StructField) (func(buffer *bytes.Buffer, value reflect.Value) error, error) {
+	switch field.Schema.Type {
+	case "RECORD":
+		fields, err := newFieldEncoders(field.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return func(buffer *bytes.Buffer, value reflect.Value) error {
+			return encodeStruct(buffer, value, fields)
+		}, nil
+	case "TIMESTAMP", "DATE", "DATETIME", "TIME":
+		return newTimeEncoder(field.Type, wire.TimeFormatter(field.Schema.Type))
+	case "NUMERIC", "BIGNUMERIC":
+		return newNumericEncoder(wire.Scale(field.Schema.Type)), nil
+	case "BYTES":
+		return func(buffer *bytes.Buffer, value reflect.Value) error {
+			if value.Kind() == reflect.String {
+				return encodeString(buffer, base64.StdEncoding.EncodeToString([]byte(value.String())))
+			}
+			return encodeString(buffer, base64.StdEncoding.EncodeToString(value.Bytes()))
+		}, nil
+	case "INTEGER", "INT64":
+		return func(buffer *bytes.Buffer, value reflect.Value) error {
+			switch value.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				buffer.WriteString(strconv.FormatInt(value.Int(), 10))
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				buffer.WriteString(strconv.FormatUint(value.Uint(), 10))
+			case reflect.String:
+				return encodeString(buffer, value.String())
+			default:
+				return fmt.Errorf("unsupported INTEGER value type: %v", value.Type())
+			}
+			return nil
+		}, nil
+	case "FLOAT", "FLOAT64":
+		return func(buffer *bytes.Buffer, value reflect.Value) error {
+			switch value.Kind() {
+			case reflect.Float32, reflect.Float64:
+				wire.EncodeFloat(buffer, value.Float(), value.Type().Bits())
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				buffer.WriteString(strconv.FormatInt(value.Int(), 10))
+			default:
+				return fmt.Errorf("unsupported FLOAT value type: %v", value.Type())
+			}
+			return nil
+		}, nil
+	case "JSON":
+		return func(buffer *bytes.Buffer, value reflect.Value) error {
+			if value.Kind() == reflect.String {
+				return encodeString(buffer, value.String())
+			}
+			data, err := json.Marshal(value.Interface())
+			if err != nil {
+				return err
+			}
+			return encodeString(buffer, string(data))
+		}, nil
+	}
+	return func(buffer *bytes.Buffer, value reflect.Value) error {
+		data, err := json.Marshal(value.Interface())
+		if err != nil {
+			return err
+		}
+		buffer.Write(data)
+		return nil
+	}, nil
+}
+
+func newTimeEncoder(rType reflect.Type, format func(t time.Time) string) (func(buffer *bytes.Buffer, value reflect.Value) error, error) {
+	if rType != timeType {
+		if rType.Kind() == reflect.String {
+			return func(buffer *bytes.Buffer, value reflect.Value) error {
+				return encodeString(buffer, value.String())
+			}, nil
+		}
+		return nil, fmt.Errorf("unsupported time value type: %v", rType)
+	}
+	return func(buffer *bytes.Buffer, value reflect.Value) error {
+		return encodeString(buffer, format(value.Interface().(time.Time)))
+	}, nil
+}
+
+func newNumericEncoder(scale int) func(buffer *bytes.Buffer, value reflect.Value) error {
+	return func(buffer *bytes.Buffer, value reflect.Value) error {
+		switch {
+		case value.Type() == ratType:
+			rat := value.Interface().(big.Rat) //copy, value may be not addressable
+			return encodeString(buffer, rat.FloatString(scale))
+		case value.Kind() == reflect.Float32 || value.Kind() == reflect.Float64:
+			return encodeString(buffer, strconv.FormatFloat(value.Float(), 'f', -1, 64))
+		case value.Kind() == reflect.String:
+			return encodeString(buffer, value.String())
+		case value.CanInt():
+			return encodeString(buffer, strconv.FormatInt(value.Int(), 10))
+		case value.CanUint():
+			return encodeString(buffer, strconv.FormatUint(value.Uint(), 10))
+		}
+		return fmt.Errorf("unsupported NUMERIC value type: %v", value.Type())
+	}
+}
+
+func encodeString(buffer *bytes.Buffer, value string) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	buffer.Write(data)
+	return nil
+}
@@ -0,0 +1,116 @@
+package reader
+
+import (
+	"io"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type encoderAddress struct {
+	City string `bigquery:"city"`
+}
+
+type encoderRecord struct {
+	ID        string           `bigquery:"id,insertID"`
+	Count     int              `bigquery:"count"`
+	Score     float64          `bigquery:"score"`
+	Amount    *big.Rat         `bigquery:"amount,type=NUMERIC"`
+	Payload   []byte           `bigquery:"payload"`
+	Created   time.Time        `bigquery:"created"`
+	Day       time.Time        `bigquery:"day,type=DATE"`
+	Comment   *string          `bigquery:"comment"`
+	Tags      []string         `bigquery:"tags"`
+	Address   encoderAddress   `bigquery:"address"`
+	Addresses []encoderAddress `bigquery:"addresses"`
+	Ignored   string           `bigquery:"-"`
+}
+
+func TestFromSlice(t *testing.T) {
+	created := time.Date(2023, 4, 5, 6, 7, 8, 123000000, time.FixedZone("X", 3600))
+	comment := "a \"quoted\" text"
+	var testCases = []struct {
+		description string
+		records     []*encoderRecord
+		expect      string
+	}{
+		{
+			description: "all types",
+			records: []*encoderRecord{
+				{
+					ID:        "1",
+					Count:     3,
+					Score:     1.5,
+					Amount:    big.NewRat(1, 4),
+					Payload:   []byte("abc"),
+					Created:   created,
+					Day:       created,
+					Comment:   &comment,
+					Tags:      []string{"x", "y"},
+					Address:   encoderAddress{City: "Austin"},
+					Addresses: []encoderAddress{{City: "Dallas"}},
+					Ignored:   "ignored",
+				},
+			},
+			expect: `{"id":"1","count":3,"score":1.5,"amount":"0.250000000","payload":"YWJj","created":"2023-04-05T05:07:08.123Z","day":"2023-04-05","comment":"a \"quoted\" text","tags":["x","y"],"address":{"city":"Austin"},"addresses":[{"city":"Dallas"}]}` + "\n",
+		},
+		{
+			description: "nulls and empty repeated",
+			records: []*encoderRecord{
+				{ID: "2", Score: math.NaN(), Created: created, Day: created},
+			},
+			expect: `{"id":"2","count":0,"score":"NaN","amount":null,"payload":"","created":"2023-04-05T05:07:08.123Z","day":"2023-04-05","comment":null,"tags":[],"address":{"city":""},"addresses":[]}` + "\n",
+		},
+	}
+	for _, testCase := range testCases {
+		encoder, err := FromSlice(testCase.records)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, "id", encoder.InsertIDField(), testCase.description)
+		actual, err := io.ReadAll(encoder)
+		assert.Nil(t, err, testCase.description)
+		assert.Equal(t, testCase.expect, string(actual), testCase.description)
+	}
+}
+
+func TestFromChannel(t *testing.T) {
+	records := make(chan encoderAddress, 2)
+	records <- encoderAddress{City: "Austin"}
+	records <- encoderAddress{City: "Dallas"}
+	close(records)
+	encoder, err := FromChannel(records)
+	if !assert.Nil(t, err) {
+		return
+	}
+	err = Register("encoder-test", encoder)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer Unregister("encoder-test")
+	if assert.NotNil(t, Schema("encoder-test")) {
+		assert.Equal(t, "city", Schema("encoder-test").Fields[0].Name)
+	}
+	assert.Equal(t, "", InsertIDField("encoder-test"))
+	actual, err := io.ReadAll(encoder)
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"city\":\"Austin\"}\n{\"city\":\"Dallas\"}\n", string(actual))
+}
+
+func TestFromSlice_Numeric(t *testing.T) {
+	type record struct {
+		Total   uint64      `bigquery:"total"`
+		Balance interface{} `bigquery:"balance,type=BIGNUMERIC"`
+	}
+	encoder, err := FromSlice([]record{{Total: math.MaxUint64, Balance: *big.NewRat(3, 2)}})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "NUMERIC", encoder.Schema().Fields[0].Type)
+	actual, err := io.ReadAll(encoder)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"total":"18446744073709551615","balance":"1.50000000000000000000000000000000000000"}`+"\n", string(actual))
+}
@@ -2,15 +2,64 @@ package reader
 
 import (
 	"fmt"
+	"github.com/viant/bigquery/internal/schema"
+	"google.golang.org/api/bigquery/v2"
 	"io"
+	"reflect"
 	"sync"
 )
 
 var readers = newRegistry()
 
-// Register registers reader
+// Register registers reader, a reader implementing Schema() *bigquery.TableSchema (i.e. Encoder)
+// also registers its load schema
 func Register(ID string, aReader io.Reader) error {
-	return readers.add(ID, aReader)
+	return readers.add(ID, aReader, nil)
+}
+
+// RegisterWithSchema registers reader with load schema derived from a record Go struct type
+func RegisterWithSchema(ID string, aReader io.Reader, record interface{}) error {
+	tableSchema, err := SchemaOf(record)
+	if err != nil {
+		return err
+	}
+	return readers.add(ID, aReader, tableSchema)
+}
+
+// SchemaOf derives table schema from a Go struct, pointers are mapped to NULLABLE, slices to REPEATED,
+// structs to RECORD and other fields to REQUIRED mode.
+// Field name, mode and type can be customized with `bigquery:"name[,nullable|required][,type=<TYPE>]"` tag.
+func SchemaOf(record interface{}) (*bigquery.TableSchema, error) {
+	if record == nil {
+		return nil, fmt.Errorf("record was nil")
+	}
+	rType, ok := record.(reflect.Type)
+	if !ok {
+		rType = reflect.TypeOf(record)
+	}
+	return schema.TableSchemaOf(rType)
+}
+
+// Schema returns schema of registered reader by ID or nil if schema was not registered
+func Schema(ID string) *bigquery.TableSchema {
+	readers.mux.Lock()
+	defer readers.mux.Unlock()
+	if aReader, ok := readers.readers[ID]; ok {
+		return aReader.schema
+	}
+	return nil
+}
+
+// InsertIDField returns stream insert ID field of registered reader by ID or empty string if reader does not define it
+func InsertIDField(ID string) string {
+	readers.mux.Lock()
+	defer readers.mux.Unlock()
+	if aReader, ok := readers.readers[ID]; ok {
+		if provider, ok := aReader.Reader.(insertIDProvider); ok {
+			return provider.InsertIDField()
+		}
+	}
+	return ""
 }
 
 // Get returns registered reader by ID
@@ -27,8 +76,17 @@ func Unregister(ID string) {
 	readers.remove(ID)
 }
 
+type schemaProvider interface {
+	Schema() *bigquery.TableSchema
+}
+
+type insertIDProvider interface {
+	InsertIDField() string
+}
+
 type reader struct {
 	io.Reader
+	schema     *bigquery.TableSchema
 	unregister func()
 }
 
@@ -49,14 +107,18 @@ func newRegistry() *registry {
 	return &registry{readers: map[string]*reader{}}
 }
 
-func (r *registry) add(ID string, aReader io.Reader) error {
+func (r *registry) add(ID string, aReader io.Reader, tableSchema *bigquery.TableSchema) error {
 	r.mux.Lock()
 	defer r.mux.Unlock()
 	if _, ok := r.readers[ID]; ok {
 		return fmt.Errorf("reader: %v, had been already registred", ID)
 	}
+	if provider, ok := aReader.(schemaProvider); ok && tableSchema == nil {
+		tableSchema = provider.Schema()
+	}
 	r.readers[ID] = &reader{
 		Reader: aReader,
+		schema: tableSchema,
 		unregister: func() {
 			r.remove(ID)
 		},
@@ -1,19 +1,26 @@
 package bigquery
 
 import (
+	"context"
+	"database/sql"
 	"database/sql/driver"
 	"fmt"
 	"github.com/francoispqt/gojay"
 	"github.com/viant/bigquery/internal"
 	"github.com/viant/bigquery/internal/query"
+	"github.com/viant/bigquery/internal/schema"
+	"github.com/viant/bigquery/internal/trace"
+	"github.com/viant/bigquery/metric"
 	"google.golang.org/api/bigquery/v2"
 	"io"
+	"math"
 	"reflect"
 	"time"
 )
 
 // Rows abstraction implements database/sql driver.Rows interface
 type Rows struct {
+	ctx           context.Context
 	session       internal.Session
 	projectID     string
 	location      string
@@ -106,6 +113,13 @@ func (r *Rows) Next(dest []driver.Value) error {
 	return nil
 }
 
+const (
+	numericPrecision    = 38
+	numericScale        = 9
+	bigNumericPrecision = 76
+	bigNumericScale     = 38
+)
+
 var timePtrType = reflect.PtrTo(reflect.TypeOf(time.Time{}))
 
 // hasNext returns true if there is next row to fetch.
@@ -133,11 +147,20 @@ func (r *Rows) fetchPage() error {
 }
 
 func (r *Rows) queryResult() (*query.Response, error) {
+	ctx, span := trace.Start(r.ctx, "bigquery.getQueryResults", trace.ProjectKey.String(r.projectID), trace.LocationKey.String(r.location), trace.JobIDKey.String(r.job.JobReference.JobId))
 	call := r.service.Jobs.GetQueryResults(r.projectID, r.job.JobReference.JobId)
 	call.Location(r.location)
 	queryCall := query.NewResultsCall(call, &r.session)
+	queryCall.Context(ctx)
 	call.PageToken(r.pageToken)
+	started := time.Now()
 	response, err := queryCall.Do()
+	metric.Observe(metric.PageFetchSeconds, time.Since(started).Seconds(), nil)
+	if err == nil {
+		metric.Add(metric.RowsDecoded, float64(len(r.session.Rows)), nil)
+		span.SetAttributes(trace.RowsKey.Int(len(r.session.Rows)), trace.TotalRowsKey.Int64(int64(r.session.TotalRows)), trace.CacheHitKey.Bool(response.CacheHit))
+	}
+	trace.End(span, err)
 	return response, err
 }
 
@@ -146,22 +169,74 @@ func (r *Rows) ColumnTypeScanType(index int) reflect.Type {
 	return r.session.DestTypes[index]
 }
 
-// ColumnTypeDatabaseTypeName returns column database type name
+// ColumnTypeDatabaseTypeName returns column database type name, REPEATED columns use ARRAY<T> and RECORD columns STRUCT<name T, ...>
 func (r *Rows) ColumnTypeDatabaseTypeName(index int) string {
-	return r.session.Schema.Fields[index].Type
+	return schema.TypeName(r.session.Schema.Fields[index])
 }
 
-// ColumnTypeNullable returns if column is nullable
+// ColumnTypeNullable returns if column is nullable, empty mode defaults to NULLABLE
 func (r *Rows) ColumnTypeNullable(index int) (nullable, ok bool) {
-	isNullable := r.session.Schema.Fields[index].Mode == "NULLABLE"
-	return isNullable, true
+	mode := r.session.Schema.Fields[index].Mode
+	return mode == "" || mode == "NULLABLE", true
+}
+
+// ColumnTypePrecisionScale returns NUMERIC and BIGNUMERIC column precision and scale
+func (r *Rows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	field := r.session.Schema.Fields[index]
+	switch schema.FieldType(field.Type) {
+	case schema.FieldTypeNumeric:
+		precision, scale = numericPrecision, numericScale
+	case schema.FieldTypeBigNumeric:
+		precision, scale = bigNumericPrecision, bigNumericScale
+	default:
+		return 0, 0, false
+	}
+	if field.Precision > 0 {
+		precision, scale = field.Precision, field.Scale
+	}
+	return precision, scale, true
+}
+
+// ColumnTypeLength returns STRING and BYTES column max length, unbounded columns return math.MaxInt64
+func (r *Rows) ColumnTypeLength(index int) (length int64, ok bool) {
+	field := r.session.Schema.Fields[index]
+	switch schema.FieldType(field.Type) {
+	case schema.FieldTypeString, schema.FieldTypeBytes:
+		if field.MaxLength > 0 {
+			return field.MaxLength, true
+		}
+		return math.MaxInt64, true
+	}
+	return 0, false
+}
+
+// ColumnTypeSchema returns column field schema including nested RECORD fields
+func (r *Rows) ColumnTypeSchema(index int) *bigquery.TableFieldSchema {
+	return r.session.Schema.Fields[index]
+}
+
+// ColumnSchema returns column field schema parsed from the column database type name, including nested RECORD fields
+func ColumnSchema(columnType *sql.ColumnType) (*bigquery.TableFieldSchema, error) {
+	result, err := schema.ParseTypeName(columnType.DatabaseTypeName())
+	if err != nil {
+		return nil, err
+	}
+	result.Name = columnType.Name()
+	if result.Mode == "" {
+		result.Mode = "NULLABLE"
+		if nullable, ok := columnType.Nullable(); ok && !nullable {
+			result.Mode = "REQUIRED"
+		}
+	}
+	return result, nil
 }
 
-func newRows(service *bigquery.Service, projectID string, location string, job *bigquery.Job) (*Rows, error) {
+func newRows(ctx context.Context, service *bigquery.Service, projectID string, location string, job *bigquery.Job) (*Rows, error) {
 	if service == nil {
 		return nil, fmt.Errorf("service was nil")
 	}
 	var result = &Rows{
+		ctx:       ctx,
 		service:   service,
 		job:       job,
 		location:  location,
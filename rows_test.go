@@ -5,12 +5,14 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/bigquery/v2"
 )
 
 func TestRows_Next(t *testing.T) {
@@ -194,3 +196,51 @@ func asTimestamp(t string) time.Time {
 	ts, _ := time.ParseInLocation(time.RFC3339, t, time.UTC)
 	return ts
 }
+
+func TestRows_ColumnTypes(t *testing.T) {
+	rows := &Rows{}
+	rows.session.Schema = &bigquery.TableSchema{Fields: []*bigquery.TableFieldSchema{
+		{Name: "id", Type: "INTEGER", Mode: "REQUIRED"},
+		{Name: "name", Type: "STRING", MaxLength: 20},
+		{Name: "payload", Type: "BYTES", Mode: "NULLABLE"},
+		{Name: "amount", Type: "NUMERIC", Precision: 10, Scale: 2},
+		{Name: "total", Type: "BIGNUMERIC"},
+		{Name: "tags", Type: "STRING", Mode: "REPEATED"},
+		{Name: "address", Type: "RECORD", Mode: "NULLABLE", Fields: []*bigquery.TableFieldSchema{
+			{Name: "city", Type: "STRING"},
+		}},
+	}}
+	var testCases = []struct {
+		description     string
+		index           int
+		expectTypeName  string
+		expectNullable  bool
+		expectLength    int64
+		expectLengthOk  bool
+		expectPrecision int64
+		expectScale     int64
+		expectNumericOk bool
+	}{
+		{description: "required integer", index: 0, expectTypeName: "INTEGER"},
+		{description: "string with max length", index: 1, expectTypeName: "STRING", expectNullable: true, expectLength: 20, expectLengthOk: true},
+		{description: "unbounded bytes", index: 2, expectTypeName: "BYTES", expectNullable: true, expectLength: math.MaxInt64, expectLengthOk: true},
+		{description: "parameterized numeric", index: 3, expectTypeName: "NUMERIC", expectNullable: true, expectPrecision: 10, expectScale: 2, expectNumericOk: true},
+		{description: "default bignumeric", index: 4, expectTypeName: "BIGNUMERIC", expectNullable: true, expectPrecision: 76, expectScale: 38, expectNumericOk: true},
+		{description: "repeated", index: 5, expectTypeName: "ARRAY<STRING>", expectLength: math.MaxInt64, expectLengthOk: true},
+		{description: "record", index: 6, expectTypeName: "STRUCT<city STRING>", expectNullable: true},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expectTypeName, rows.ColumnTypeDatabaseTypeName(testCase.index), testCase.description)
+		nullable, ok := rows.ColumnTypeNullable(testCase.index)
+		assert.True(t, ok, testCase.description)
+		assert.Equal(t, testCase.expectNullable, nullable, testCase.description)
+		length, ok := rows.ColumnTypeLength(testCase.index)
+		assert.Equal(t, testCase.expectLengthOk, ok, testCase.description)
+		assert.Equal(t, testCase.expectLength, length, testCase.description)
+		precision, scale, ok := rows.ColumnTypePrecisionScale(testCase.index)
+		assert.Equal(t, testCase.expectNumericOk, ok, testCase.description)
+		assert.Equal(t, testCase.expectPrecision, precision, testCase.description)
+		assert.Equal(t, testCase.expectScale, scale, testCase.description)
+		assert.Equal(t, rows.session.Schema.Fields[testCase.index], rows.ColumnTypeSchema(testCase.index), testCase.description)
+	}
+}
@@ -5,6 +5,10 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"github.com/viant/bigquery/internal/exec"
+	"github.com/viant/bigquery/internal/label"
+	"github.com/viant/bigquery/internal/logging"
+	"github.com/viant/bigquery/internal/trace"
+	"github.com/viant/bigquery/metric"
 	"google.golang.org/api/bigquery/v2"
 	"strings"
 )
@@ -13,9 +17,17 @@ import (
 type Statement struct {
 	projectID string
 	location  string
-	service   *bigquery.Service
+	service   *bigquery.Service //connection service, context credentials service is resolved at execution, see contextService
 	job       *bigquery.Job
+	labels    map[string]string
 	numInput  int
+	logger    *logging.Logger
+	shared    *sharedService
+}
+
+// contextService returns service of the credentials carried by ctx, see WithTokenSource
+func (s *Statement) contextService(ctx context.Context) (*bigquery.Service, error) {
+	return s.shared.contextService(ctx, s.service)
 }
 
 func (s *Statement) submitJob(ctx context.Context) (*bigquery.Job, error) {
@@ -25,13 +37,28 @@ func (s *Statement) submitJob(ctx context.Context) (*bigquery.Job, error) {
 	}
 	queryJob.JobReference.ProjectId = s.projectID
 	queryJob.JobReference.Location = s.location
+	queryJob.Configuration.Labels = label.Merge(s.labels, label.FromContext(ctx))
+	if err := label.Validate(queryJob.Configuration.Labels); err != nil {
+		return nil, err
+	}
+	service, err := s.contextService(ctx)
+	if err != nil {
+		return nil, err
+	}
 	var job *bigquery.Job
-	var err error
-	err = exec.RunWithRetries(func() error {
-		jobCall := s.service.Jobs.Insert(s.projectID, queryJob)
+	ctx = logging.WithDefault(ctx, s.logger)
+	ctx, span := trace.Start(ctx, "bigquery.submitJob", trace.ProjectKey.String(s.projectID), trace.LocationKey.String(s.location), trace.JobKindKey.String("query"))
+	err = exec.RunWithRetries(ctx, func() error {
+		jobCall := service.Jobs.Insert(s.projectID, queryJob)
 		job, err = jobCall.Context(ctx).Do()
 		return err
 	}, 3)
+	if err == nil {
+		metric.Add(metric.JobsSubmitted, 1, metric.Kind("query"))
+		logging.FromContext(ctx).JobSubmitted(ctx, "query", job)
+	}
+	trace.SetJob(span, job)
+	trace.End(span, err)
 	return job, err
 }
 
@@ -53,13 +80,23 @@ func (s *Statement) ExecContext(ctx context.Context, args []driver.NamedValue) (
 	return s.exec(ctx, params)
 }
 
-func (s *Statement) exec(ctx context.Context, params []*bigquery.QueryParameter) (driver.Result, error) {
+func (s *Statement) exec(ctx context.Context, params []*bigquery.QueryParameter) (_ driver.Result, err error) {
+	ctx, span := trace.Start(logging.WithDefault(ctx, s.logger), "bigquery.Exec")
+	defer func() { trace.End(span, err) }()
 	s.job.Configuration.Query.QueryParameters = params
 	job, err := s.submitJob(ctx)
 	if err != nil {
 		return nil, err
 	}
-	completed, err := exec.WaitForJobCompletion(ctx, s.service, s.projectID, s.location, job.JobReference.JobId)
+	if s.job.Configuration.DryRun {
+		return &result{}, nil
+	}
+	service, err := s.contextService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	completed, err := exec.WaitForJobCompletion(ctx, service, s.projectID, s.location, job.JobReference.JobId)
+	trace.SetJob(span, completed)
 	if err != nil {
 		return nil, fmt.Errorf("failed to run job: %v.%v, %w", job.JobReference.ProjectId, job.JobReference.JobId, err)
 	}
@@ -90,18 +127,31 @@ func (s *Statement) QueryContext(ctx context.Context, args []driver.NamedValue)
 	return s.query(ctx, params)
 }
 
-func (s *Statement) query(ctx context.Context, params []*bigquery.QueryParameter) (driver.Rows, error) {
+func (s *Statement) query(ctx context.Context, params []*bigquery.QueryParameter) (_ driver.Rows, err error) {
+	ctx = logging.WithDefault(ctx, s.logger)
+	queryCtx, span := trace.Start(ctx, "bigquery.Query")
+	defer func() { trace.End(span, err) }()
 	s.job.Configuration.Query.QueryParameters = params
-	job, err := s.submitJob(ctx)
+	job, err := s.submitJob(queryCtx)
 	if err != nil {
 		return nil, fmt.Errorf("%w, SQL: %v", err, s.job.Configuration.Query.Query)
 	}
+	if s.job.Configuration.DryRun {
+		return dryRunRows(job)
+	}
+	service, err := s.contextService(queryCtx)
+	if err != nil {
+		return nil, err
+	}
 	if job.Status.State != exec.StatusDone {
-		if _, err = exec.WaitForJobCompletion(ctx, s.service, s.projectID, s.location, job.JobReference.JobId); err != nil {
+		completed, err := exec.WaitForJobCompletion(queryCtx, service, s.projectID, s.location, job.JobReference.JobId)
+		if err != nil {
 			return nil, fmt.Errorf("%w, SQL: %v", err, s.job.Configuration.Query.Query)
 		}
+		job = completed
 	}
-	return newRows(s.service, s.projectID, s.location, job)
+	trace.SetJob(span, job)
+	return newRows(ctx, service, s.projectID, s.location, job)
 }
 
 // Close closes statement
@@ -156,3 +206,12 @@ func checkQueryParameters(query string) int {
 	}
 	return count
 }
+
+// dryRunRows returns rows without data, columns are taken from the dry run result schema
+func dryRunRows(job *bigquery.Job) (driver.Rows, error) {
+	tableSchema := &bigquery.TableSchema{}
+	if stats := job.Statistics; stats != nil && stats.Query != nil && stats.Query.Schema != nil {
+		tableSchema = stats.Query.Schema
+	}
+	return newCommandRows(tableSchema, nil)
+}
@@ -0,0 +1,101 @@
+package bigquery
+
+import (
+	"crypto/tls"
+	"errors"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const defaultMaxIdleConnsPerHost = 32
+
+// sharedService represents authenticated service and HTTP client shared by connector connections
+type sharedService struct {
+	service    *bigquery.Service
+	client     *http.Client
+	version    string
+	stale      atomic.Bool
+	base       *http.Transport
+	options    []option.ClientOption
+	scopes     []string
+	identities *identityCache
+}
+
+// observe returns HTTP client marking the shared service stale on authentication failures
+func (s *sharedService) observe(transport http.RoundTripper) *http.Client {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &http.Client{Transport: &authObserver{next: transport, shared: s}}
+}
+
+// authObserver represents round tripper observing authentication failures, i.e. revoked or rotated credentials
+type authObserver struct {
+	next   http.RoundTripper
+	shared *sharedService
+}
+
+// RoundTrip executes HTTP request
+func (o *authObserver) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := o.next.RoundTrip(request)
+	if isAuthFailure(response, err) {
+		o.shared.stale.Store(true)
+	}
+	return response, err
+}
+
+func isAuthFailure(response *http.Response, err error) bool {
+	if err != nil {
+		var retrieveError *oauth2.RetrieveError
+		return errors.As(err, &retrieveError)
+	}
+	return response != nil && response.StatusCode == http.StatusUnauthorized
+}
+
+// transport returns base HTTP transport tuned with config settings
+func (c *Config) transport() *http.Transport {
+	result := http.DefaultTransport.(*http.Transport).Clone()
+	result.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	if c.MaxIdleConns > 0 {
+		result.MaxIdleConns = c.MaxIdleConns
+	}
+	if c.MaxIdleConnsPerHost > 0 {
+		result.MaxIdleConnsPerHost = c.MaxIdleConnsPerHost
+	}
+	if c.IdleConnTimeout > 0 {
+		result.IdleConnTimeout = c.IdleConnTimeout
+	}
+	if c.DisableHTTP2 {
+		result.ForceAttemptHTTP2 = false
+		result.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return result
+}
+
+// scopes returns configured or default OAuth2 scopes
+func (c *Config) scopes() []string {
+	if len(c.Scopes) > 0 {
+		return c.Scopes
+	}
+	return []string{bigquery.BigqueryScope, bigquery.CloudPlatformScope}
+}
+
+// credentialsVersion returns version of file based credentials, a changed version indicates rotated credentials
+func (c *Config) credentialsVersion() string {
+	var result []string
+	for _, location := range []string{c.CredentialsFile, os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")} {
+		if location == "" {
+			continue
+		}
+		if info, err := os.Stat(location); err == nil {
+			result = append(result, location+"@"+strconv.FormatInt(info.ModTime().UnixNano(), 10)+":"+strconv.FormatInt(info.Size(), 10))
+		}
+	}
+	return strings.Join(result, ",")
+}
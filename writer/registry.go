@@ -0,0 +1,62 @@
+package writer
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+var writers = newRegistry()
+
+// Register registers writer receiving exported data
+func Register(ID string, aWriter io.Writer) error {
+	return writers.add(ID, aWriter)
+}
+
+// Get returns registered writer by ID
+func Get(ID string) (io.Writer, error) {
+	result := writers.get(ID)
+	if result == nil {
+		return nil, fmt.Errorf("unknown writer: %s", ID)
+	}
+	return result, nil
+}
+
+// Unregister unregisters writer
+func Unregister(ID string) {
+	writers.remove(ID)
+}
+
+type registry struct {
+	mux     sync.Mutex
+	writers map[string]io.Writer
+}
+
+func newRegistry() *registry {
+	return &registry{writers: map[string]io.Writer{}}
+}
+
+func (r *registry) add(ID string, aWriter io.Writer) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if _, ok := r.writers[ID]; ok {
+		return fmt.Errorf("writer: %v, had been already registred", ID)
+	}
+	r.writers[ID] = aWriter
+	return nil
+}
+
+func (r *registry) remove(ID string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	delete(r.writers, ID)
+}
+
+func (r *registry) get(ID string) io.Writer {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if aWriter, ok := r.writers[ID]; ok {
+		return aWriter
+	}
+	return nil
+}